@@ -0,0 +1,114 @@
+package animation
+
+import (
+	"github.com/danieltmartin/ray-tracer/camera"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/light"
+	"github.com/danieltmartin/ray-tracer/material"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/primitive"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/danieltmartin/ray-tracer/world"
+)
+
+// Animation is a set of tracks bound to the things in a scene they change.
+type Animation struct {
+	bindings []binding
+	shutter  float64
+}
+
+// binding applies a track to the scene. Bindings that move things are also given the frame
+// the shutter closes at, so they can blur the movement in between.
+type binding func(frame, shutterClose float64)
+
+func New() *Animation {
+	return &Animation{}
+}
+
+// SetShutter sets how many frames the shutter stays open for. When it is more than 0, bound
+// transforms move from their value at each frame to their value that many frames later,
+// turning smoothly as transform.Motion does, and cameras with a shutter interval from 0 to 1
+// blur the movement.
+func (a *Animation) SetShutter(frames float64) {
+	if frames < 0 {
+		panic("shutter can't be open for less than 0 frames")
+	}
+	a.shutter = frames
+}
+
+// Bind calls apply with the value of track at each frame, to animate anything in the scene.
+func Bind[T any](a *Animation, track Track[T], apply func(value T)) {
+	a.bindings = append(a.bindings, func(frame, _ float64) { apply(track.At(frame)) })
+}
+
+// Transform animates the transform of p.
+func (a *Animation) Transform(p primitive.Primitive, track Track[matrix.Matrix]) {
+	a.bindings = append(a.bindings, func(frame, shutterClose float64) {
+		if frame == shutterClose {
+			p.SetTransform(track.At(frame))
+		} else {
+			p.SetMotion(shutterMotion(track, frame, shutterClose))
+		}
+	})
+}
+
+// CameraTransform animates the view transform of c.
+func (a *Animation) CameraTransform(c *camera.Camera, track Track[matrix.Matrix]) {
+	a.bindings = append(a.bindings, func(frame, shutterClose float64) {
+		if frame == shutterClose {
+			c.SetTransform(track.At(frame))
+		} else {
+			c.SetMotion(shutterMotion(track, frame, shutterClose))
+		}
+	})
+}
+
+// shutterMotion returns the movement of track from frame to shutterClose as a Motion over the
+// shutter interval from 0 to 1. It is keyed at both ends and at the keys of the track in
+// between, so that it follows the track through turns and changes of direction.
+func shutterMotion(track Track[matrix.Matrix], frame, shutterClose float64) transform.Motion {
+	keys := []transform.Key{{Time: 0, Matrix: track.At(frame)}}
+	for _, k := range track.Keys() {
+		if k.Frame > frame && k.Frame < shutterClose {
+			keys = append(keys, transform.Key{Time: (k.Frame - frame) / (shutterClose - frame), Matrix: k.Value})
+		}
+	}
+	keys = append(keys, transform.Key{Time: 1, Matrix: track.At(shutterClose)})
+	return transform.NewMotion(keys...)
+}
+
+// LightPosition animates the position of l.
+func (a *Animation) LightPosition(l *light.PointLight, track Track[tuple.Tuple]) {
+	Bind(a, track, l.SetPosition)
+}
+
+// LightIntensity animates the intensity of l.
+func (a *Animation) LightIntensity(l *light.PointLight, track Track[floatcolor.Float64Color]) {
+	Bind(a, track, l.SetIntensity)
+}
+
+// Material animates a parameter of the material of p, set by with, which is usually a method
+// expression such as material.Material.WithReflective.
+func (a *Animation) Material(p primitive.Primitive, track Track[float64], with func(material.Material, float64) material.Material) {
+	Bind(a, track, func(value float64) { p.SetMaterial(with(p.Material(), value)) })
+}
+
+// MaterialColor animates a color of the material of p, set by with, which is usually a method
+// expression such as material.Material.WithColor.
+func (a *Animation) MaterialColor(p primitive.Primitive, track Track[floatcolor.Float64Color], with func(material.Material, floatcolor.Float64Color) material.Material) {
+	Bind(a, track, func(value floatcolor.Float64Color) { p.SetMaterial(with(p.Material(), value)) })
+}
+
+// Apply sets everything the animation changes to how it is at frame, then updates the bounds
+// of the groups in w to fit primitives that have moved.
+func (a *Animation) Apply(w *world.World, frame float64) {
+	for _, b := range a.bindings {
+		b(frame, frame+a.shutter)
+	}
+	for _, p := range w.Primitives() {
+		if u, ok := p.(interface{ UpdateBounds() }); ok {
+			u.UpdateBounds()
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package animation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/camera"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/light"
+	"github.com/danieltmartin/ray-tracer/material"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/primitive"
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/danieltmartin/ray-tracer/world"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func slide() Track[matrix.Matrix] {
+	return NewMatrixTrack(
+		Key[matrix.Matrix]{Frame: 0, Value: transform.Translation(0, 0, 0)},
+		Key[matrix.Matrix]{Frame: 10, Value: transform.Translation(10, 0, 0)},
+	)
+}
+
+func TestApplyTransformUpdatesGroupBounds(t *testing.T) {
+	s := primitive.NewSphere()
+	g := primitive.NewGroup()
+	g.Add(&s)
+	w := world.New()
+	w.AddPrimitives(g)
+	a := New()
+	a.Transform(&s, slide())
+
+	a.Apply(w, 4)
+
+	assert.Equal(t, transform.Translation(4, 0, 0), s.Transform())
+	assert.Equal(t, tuple.NewPoint(3, -1, -1), g.Bounds().Min())
+	assert.Equal(t, tuple.NewPoint(5, 1, 1), g.Bounds().Max())
+}
+
+func TestApplyWithShutterSetsMotion(t *testing.T) {
+	s := primitive.NewSphere()
+	w := world.New()
+	w.AddPrimitives(&s)
+	a := New()
+	a.SetShutter(0.5)
+	a.Transform(&s, slide())
+
+	a.Apply(w, 4)
+
+	r := ray.New(tuple.NewPoint(4.5, 0, -5), tuple.NewVector(0, 0, 1))
+	assert.Equal(t, transform.Translation(4, 0, 0), s.Transform())
+	xs := s.Intersects(r.WithTime(1))
+	require.Len(t, xs, 2)
+	assert.Equal(t, 4.0, xs[0].Distance())
+}
+
+func TestApplyWithShutterFollowsTrackThroughTurns(t *testing.T) {
+	var keys []Key[matrix.Matrix]
+	for frame := 0; frame <= 3; frame++ {
+		keys = append(keys, Key[matrix.Matrix]{Frame: float64(frame), Value: transform.Identity().Translation(2, 0, 0).RotationZ(float64(frame) * math.Pi / 2).Matrix()})
+	}
+	s := primitive.NewSphere()
+	w := world.New()
+	w.AddPrimitives(&s)
+	a := New()
+	a.SetShutter(3)
+	track := NewTransformTrack(keys...)
+	a.Transform(&s, track)
+
+	a.Apply(w, 0)
+
+	// Half way through the shutter the sphere is where the track has it half way round, not the
+	// short way back from the end of the turn.
+	center := track.At(1.5).MulTuple(tuple.NewPoint(0, 0, 0))
+	assert.Less(t, center.X, 0.0)
+	r := ray.New(tuple.NewPoint(center.X, center.Y, -5), tuple.NewVector(0, 0, 1))
+	xs := s.Intersects(r.WithTime(0.5))
+	require.Len(t, xs, 2)
+	test.AssertAlmost(t, 4.0, xs[0].Distance())
+}
+
+func TestApplyCameraLightAndMaterial(t *testing.T) {
+	c := camera.New(11, 11, math.Pi/2)
+	l := light.NewPointLight(tuple.NewPoint(0, 0, 0), floatcolor.White)
+	s := primitive.NewSphere()
+	a := New()
+	a.CameraTransform(c, slide())
+	a.LightPosition(&l, NewTupleTrack(
+		Key[tuple.Tuple]{Frame: 0, Value: tuple.NewPoint(0, 10, 0)},
+		Key[tuple.Tuple]{Frame: 10, Value: tuple.NewPoint(0, 20, 0)},
+	))
+	a.LightIntensity(&l, NewColorTrack(
+		Key[floatcolor.Float64Color]{Frame: 0, Value: floatcolor.White},
+		Key[floatcolor.Float64Color]{Frame: 10, Value: floatcolor.Black},
+	))
+	a.Material(&s, NewFloatTrack(
+		Key[float64]{Frame: 0, Value: 0},
+		Key[float64]{Frame: 10, Value: 1},
+	), material.Material.WithReflective)
+	a.MaterialColor(&s, NewColorTrack(
+		Key[floatcolor.Float64Color]{Frame: 0, Value: floatcolor.Red},
+		Key[floatcolor.Float64Color]{Frame: 10, Value: floatcolor.Blue},
+	), material.Material.WithColor)
+
+	a.Apply(world.New(), 5)
+
+	assert.Equal(t, tuple.NewPoint(-5, 0, 0), c.RayForPixel(5, 5).Origin())
+	assert.Equal(t, tuple.NewPoint(0, 15, 0), l.Position())
+	assert.Equal(t, floatcolor.New(0.5, 0.5, 0.5), l.Intensity())
+	assert.Equal(t, 0.5, s.Material().Reflective())
+	assert.Equal(t, floatcolor.New(0.5, 0, 0.5), s.Material().ColorAt(&s, tuple.NewPoint(1, 0, 0)))
+}
+
+func TestBindAnimatesAnything(t *testing.T) {
+	s := primitive.NewSphere()
+	a := New()
+	Bind(a, NewFloatTrack(
+		Key[float64]{Frame: 0, Value: 0},
+		Key[float64]{Frame: 4, Value: math.Pi},
+	), func(angle float64) { s.SetTransform(transform.RotationY(angle)) })
+
+	a.Apply(world.New(), 2)
+
+	assert.Equal(t, transform.RotationY(math.Pi/2), s.Transform())
+}
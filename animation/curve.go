@@ -0,0 +1,36 @@
+// Package animation changes scenes over a range of frames, by keyframing the transforms of
+// cameras and primitives, lights and material parameters, and renders the frames to files.
+package animation
+
+// Curve shapes the transition between two keys, mapping the fraction of the time between them
+// that has passed, from 0 to 1, to the fraction of the way from the first value to the second.
+type Curve func(t float64) float64
+
+// Linear changes at a constant rate.
+func Linear(t float64) float64 {
+	return t
+}
+
+// Step holds the first value until the next key.
+func Step(t float64) float64 {
+	if t < 1 {
+		return 0
+	}
+	return 1
+}
+
+// EaseIn starts slowly and speeds up.
+func EaseIn(t float64) float64 {
+	return t * t * t
+}
+
+// EaseOut starts quickly and slows down.
+func EaseOut(t float64) float64 {
+	t = 1 - t
+	return 1 - t*t*t
+}
+
+// EaseInOut starts and ends slowly.
+func EaseInOut(t float64) float64 {
+	return t * t * (3 - 2*t)
+}
@@ -0,0 +1,29 @@
+package animation
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCurvesRunFromZeroToOne(t *testing.T) {
+	for name, c := range map[string]Curve{
+		"linear":    Linear,
+		"step":      Step,
+		"easeIn":    EaseIn,
+		"easeOut":   EaseOut,
+		"easeInOut": EaseInOut,
+	} {
+		assert.Equal(t, 0.0, c(0), name)
+		assert.Equal(t, 1.0, c(1), name)
+	}
+}
+
+func TestCurveShapes(t *testing.T) {
+	assert.Equal(t, 0.5, Linear(0.5))
+	assert.Equal(t, 0.0, Step(0.99))
+	assert.Less(t, EaseIn(0.5), 0.5)
+	assert.Greater(t, EaseOut(0.5), 0.5)
+	assert.Equal(t, 0.5, EaseInOut(0.5))
+	assert.Less(t, EaseInOut(0.25), 0.25)
+}
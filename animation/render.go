@@ -0,0 +1,65 @@
+package animation
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/danieltmartin/ray-tracer/camera"
+	"github.com/danieltmartin/ray-tracer/image/hdr"
+	"github.com/danieltmartin/ray-tracer/image/ppm"
+	"github.com/danieltmartin/ray-tracer/world"
+)
+
+// RenderFrames renders each frame from first to last inclusive with c, after applying the
+// animation to it. Each frame is written to a file named by formatting pattern with the frame
+// number, such as "frames/turntable%04d.png", in the format given by its extension, which may
+// be .png, .ppm or .hdr.
+func RenderFrames(a *Animation, c *camera.Camera, w *world.World, first, last int, pattern string) error {
+	encode, err := encoderFor(pattern)
+	if err != nil {
+		return err
+	}
+	for frame := first; frame <= last; frame++ {
+		a.Apply(w, float64(frame))
+		if err := writeImage(fmt.Sprintf(pattern, frame), c.Render(w), encode); err != nil {
+			return fmt.Errorf("frame %v: %v", frame, err)
+		}
+	}
+	return nil
+}
+
+func encoderFor(pattern string) (func(io.Writer, image.Image) error, error) {
+	switch ext := strings.ToLower(filepath.Ext(pattern)); ext {
+	case ".png":
+		return png.Encode, nil
+	case ".ppm":
+		return ppm.Encode, nil
+	case ".hdr":
+		return hdr.Encode, nil
+	default:
+		return nil, fmt.Errorf("unsupported image format %q", ext)
+	}
+}
+
+func writeImage(name string, img image.Image, encode func(io.Writer, image.Image) error) error {
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(f)
+	if err := encode(w, img); err != nil {
+		f.Close()
+		return err
+	}
+	if err := w.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,50 @@
+package animation
+
+import (
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/camera"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/light"
+	"github.com/danieltmartin/ray-tracer/primitive"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/danieltmartin/ray-tracer/world"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderFramesWritesNumberedFiles(t *testing.T) {
+	dir := t.TempDir()
+	w := world.New()
+	l := light.NewPointLight(tuple.NewPoint(-10, 10, -10), floatcolor.White)
+	w.AddLights(&l)
+	s := primitive.NewSphere()
+	w.AddPrimitives(&s)
+	c := camera.New(4, 3, math.Pi/2)
+	a := New()
+	a.Transform(&s, slide())
+
+	err := RenderFrames(a, c, w, 2, 4, filepath.Join(dir, "frame%03d.png"))
+
+	require.NoError(t, err)
+	for _, name := range []string{"frame002.png", "frame003.png", "frame004.png"} {
+		f, err := os.Open(filepath.Join(dir, name))
+		require.NoError(t, err)
+		img, err := png.Decode(f)
+		f.Close()
+		require.NoError(t, err)
+		assert.Equal(t, 4, img.Bounds().Dx())
+	}
+	_, err = os.Stat(filepath.Join(dir, "frame005.png"))
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestRenderFramesRejectsUnknownFormat(t *testing.T) {
+	err := RenderFrames(New(), camera.New(4, 3, math.Pi/2), world.New(), 0, 1, "frame%d.gif")
+
+	assert.EqualError(t, err, `unsupported image format ".gif"`)
+}
@@ -0,0 +1,95 @@
+package animation
+
+import (
+	"sort"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Key is a value at a frame. Curve shapes the transition from this key to the next, and is
+// Linear if nil.
+type Key[T any] struct {
+	Frame float64
+	Value T
+	Curve Curve
+}
+
+// Track is a value that changes over frames, given by keys it is interpolated between. Before
+// the first key and after the last it holds still.
+type Track[T any] struct {
+	keys []Key[T]
+	lerp func(a, b T, t float64) T
+}
+
+// NewTrack creates a Track through keys, which may be given in any order, using lerp to
+// interpolate between two values.
+func NewTrack[T any](lerp func(a, b T, t float64) T, keys ...Key[T]) Track[T] {
+	if len(keys) == 0 {
+		panic("track needs at least one key")
+	}
+	sorted := make([]Key[T], len(keys))
+	copy(sorted, keys)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Frame < sorted[j].Frame })
+	return Track[T]{sorted, lerp}
+}
+
+// NewFloatTrack creates a Track of numbers, such as angles or material parameters.
+func NewFloatTrack(keys ...Key[float64]) Track[float64] {
+	return NewTrack(func(a, b, t float64) float64 { return a + (b-a)*t }, keys...)
+}
+
+// NewTupleTrack creates a Track of points or vectors.
+func NewTupleTrack(keys ...Key[tuple.Tuple]) Track[tuple.Tuple] {
+	return NewTrack(func(a, b tuple.Tuple, t float64) tuple.Tuple { return a.Add(b.Sub(a).Mul(t)) }, keys...)
+}
+
+// NewColorTrack creates a Track of colors, interpolating each channel linearly so that light
+// intensities brighter than white keep their brightness.
+func NewColorTrack(keys ...Key[floatcolor.Float64Color]) Track[floatcolor.Float64Color] {
+	lerp := func(a, b floatcolor.Float64Color, t float64) floatcolor.Float64Color {
+		return a.Add(b.Sub(a).Mul(t))
+	}
+	return NewTrack(lerp, keys...)
+}
+
+// NewMatrixTrack creates a Track of transforms, interpolating each element of the matrices.
+// This suits translation and scaling, but rotations shrink part way between keys, so turning
+// is better animated with NewTransformTrack.
+func NewMatrixTrack(keys ...Key[matrix.Matrix]) Track[matrix.Matrix] {
+	return NewTrack(func(a, b matrix.Matrix, t float64) matrix.Matrix { return a.Lerp(b, t) }, keys...)
+}
+
+// NewTransformTrack creates a Track of transforms that decomposes them into translation,
+// rotation and scale and interpolates those, so that objects and cameras turn smoothly between
+// orientations. Shearing in the keys is lost.
+func NewTransformTrack(keys ...Key[matrix.Matrix]) Track[matrix.Matrix] {
+	lerp := func(a, b matrix.Matrix, t float64) matrix.Matrix {
+		return transform.Decompose(a).Lerp(transform.Decompose(b), t).Matrix()
+	}
+	return NewTrack(lerp, keys...)
+}
+
+// Keys returns the keys of the track in frame order.
+func (tr Track[T]) Keys() []Key[T] {
+	return tr.keys
+}
+
+// At returns the value at frame.
+func (tr Track[T]) At(frame float64) T {
+	i := sort.Search(len(tr.keys), func(i int) bool { return tr.keys[i].Frame > frame })
+	if i == 0 {
+		return tr.keys[0].Value
+	}
+	if i == len(tr.keys) {
+		return tr.keys[len(tr.keys)-1].Value
+	}
+	k1, k2 := tr.keys[i-1], tr.keys[i]
+	curve := k1.Curve
+	if curve == nil {
+		curve = Linear
+	}
+	return tr.lerp(k1.Value, k2.Value, curve((frame-k1.Frame)/(k2.Frame-k1.Frame)))
+}
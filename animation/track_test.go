@@ -0,0 +1,76 @@
+package animation
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFloatTrack(t *testing.T) {
+	tr := NewFloatTrack(
+		Key[float64]{Frame: 10, Value: 4},
+		Key[float64]{Frame: 0, Value: 0},
+		Key[float64]{Frame: 20, Value: 0, Curve: Step},
+		Key[float64]{Frame: 30, Value: 8},
+	)
+
+	assert.Equal(t, 0.0, tr.At(-5))
+	assert.Equal(t, 1.0, tr.At(2.5))
+	assert.Equal(t, 4.0, tr.At(10))
+	assert.Equal(t, 2.0, tr.At(15))
+	assert.Equal(t, 0.0, tr.At(29))
+	assert.Equal(t, 8.0, tr.At(30))
+	assert.Equal(t, 8.0, tr.At(100))
+	assert.Equal(t, 10.0, tr.Keys()[1].Frame)
+}
+
+func TestTrackUsesCurveOfEarlierKey(t *testing.T) {
+	tr := NewFloatTrack(
+		Key[float64]{Frame: 0, Value: 0, Curve: EaseIn},
+		Key[float64]{Frame: 10, Value: 10},
+	)
+
+	assert.Equal(t, 1.25, tr.At(5))
+}
+
+func TestTupleColorAndMatrixTracks(t *testing.T) {
+	points := NewTupleTrack(
+		Key[tuple.Tuple]{Frame: 0, Value: tuple.NewPoint(0, 0, 0)},
+		Key[tuple.Tuple]{Frame: 4, Value: tuple.NewPoint(4, 8, 0)},
+	)
+	colors := NewColorTrack(
+		Key[floatcolor.Float64Color]{Frame: 0, Value: floatcolor.Black},
+		Key[floatcolor.Float64Color]{Frame: 4, Value: floatcolor.New(4, 2, 0)},
+	)
+	matrices := NewMatrixTrack(
+		Key[matrix.Matrix]{Frame: 0, Value: transform.Translation(0, 0, 0)},
+		Key[matrix.Matrix]{Frame: 4, Value: transform.Translation(0, 0, 8)},
+	)
+
+	assert.Equal(t, tuple.NewPoint(1, 2, 0), points.At(1))
+	assert.Equal(t, floatcolor.New(1, 0.5, 0), colors.At(1))
+	assert.Equal(t, transform.Translation(0, 0, 2), matrices.At(1))
+}
+
+func TestTrackWithoutKeysPanics(t *testing.T) {
+	assert.Panics(t, func() { NewFloatTrack() })
+}
+
+func TestTransformTrackTurnsSmoothly(t *testing.T) {
+	track := NewTransformTrack(
+		Key[matrix.Matrix]{Frame: 0, Value: transform.Identity().Translation(2, 0, 0).Matrix()},
+		Key[matrix.Matrix]{Frame: 2, Value: transform.Identity().RotationY(math.Pi).Translation(2, 0, 0).Matrix()},
+	)
+	point := tuple.NewPoint(1, 0, 0)
+
+	assert.True(t, transform.Translation(2, 0, 0).Equals(track.At(0)))
+	assert.True(t, transform.Identity().RotationY(math.Pi/2).Translation(2, 0, 0).Matrix().Equals(track.At(1)))
+	// Interpolating the matrices would pass the point through the origin of the object.
+	test.AssertAlmost(t, 1.0, track.At(1).MulTuple(point).Sub(tuple.NewPoint(2, 0, 0)).Mag())
+}
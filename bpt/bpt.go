@@ -0,0 +1,133 @@
+// Package bpt reads Bezier patch files in the format used for the classic Utah teapot. A file
+// starts with the number of patches, followed by each patch as a line giving its degree in u
+// and v, which must both be 3, and then its 16 control points, one per line.
+package bpt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/danieltmartin/ray-tracer/primitive"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Parse reads the patches in r and tessellates each into a group of triangles at the given
+// level, returning them in a single group.
+func Parse(r io.Reader, level int) (primitive.Primitive, error) {
+	patches, err := ParsePatches(r)
+	if err != nil {
+		return nil, err
+	}
+	group := primitive.NewGroup()
+	for _, p := range patches {
+		group.Add(p.Tessellate(level))
+	}
+	return group, nil
+}
+
+// ParsePatches reads the patches in r.
+func ParsePatches(r io.Reader) ([]primitive.BezierPatch, error) {
+	lines := newLineReader(r)
+
+	tokens, err := lines.next()
+	if err != nil {
+		return nil, err
+	}
+	if len(tokens) != 1 {
+		return nil, lines.errorf("expected patch count")
+	}
+	count, err := strconv.ParseUint(tokens[0], 10, 64)
+	if err != nil {
+		return nil, lines.errorf("bad patch count: %v", err)
+	}
+
+	// The count comes from the file, so patches are only allocated as they're read.
+	var patches []primitive.BezierPatch
+	for i := uint64(0); i < count; i++ {
+		tokens, err := lines.next()
+		if err != nil {
+			if lines.done {
+				return nil, lines.errorf("expected %v patches but found %v", count, i)
+			}
+			return nil, err
+		}
+		patch, err := parsePatch(lines, tokens)
+		if err != nil {
+			return nil, err
+		}
+		patches = append(patches, patch)
+	}
+	return patches, nil
+}
+
+// parsePatch reads the patch whose degrees are given by tokens.
+func parsePatch(lines *lineReader, tokens []string) (primitive.BezierPatch, error) {
+	if len(tokens) != 2 {
+		return primitive.BezierPatch{}, lines.errorf("expected patch degrees")
+	}
+	if tokens[0] != "3" || tokens[1] != "3" {
+		return primitive.BezierPatch{}, lines.errorf("only bicubic patches are supported")
+	}
+
+	var points [16]tuple.Tuple
+	for i := range points {
+		tokens, err := lines.next()
+		if err != nil {
+			return primitive.BezierPatch{}, err
+		}
+		points[i], err = parsePoint(tokens)
+		if err != nil {
+			return primitive.BezierPatch{}, lines.errorf("%v", err)
+		}
+	}
+	return primitive.NewBezierPatch(points), nil
+}
+
+func parsePoint(tokens []string) (tuple.Tuple, error) {
+	if len(tokens) != 3 {
+		return tuple.Tuple{}, fmt.Errorf("expected 3 coordinates for control point")
+	}
+	var coords [3]float64
+	for i, token := range tokens {
+		f, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return tuple.Tuple{}, fmt.Errorf("bad float: %v", err)
+		}
+		coords[i] = f
+	}
+	return tuple.NewPoint(coords[0], coords[1], coords[2]), nil
+}
+
+// lineReader returns the fields of each non-blank line, keeping track of line numbers for
+// error messages.
+type lineReader struct {
+	sc      *bufio.Scanner
+	lineNum int
+	done    bool // Whether the end of the file has been reached
+}
+
+func newLineReader(r io.Reader) *lineReader {
+	return &lineReader{bufio.NewScanner(r), 0, false}
+}
+
+func (l *lineReader) next() ([]string, error) {
+	for l.sc.Scan() {
+		l.lineNum++
+		tokens := strings.Fields(l.sc.Text())
+		if len(tokens) > 0 {
+			return tokens, nil
+		}
+	}
+	if err := l.sc.Err(); err != nil {
+		return nil, err
+	}
+	l.done = true
+	return nil, fmt.Errorf("line %v: unexpected end of file", l.lineNum)
+}
+
+func (l *lineReader) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("line %v: %v", l.lineNum, fmt.Sprintf(format, args...))
+}
@@ -0,0 +1,94 @@
+package bpt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/primitive"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const twoPatches = `2
+3 3
+0 0 0
+1 0 0
+2 0 0
+3 0 0
+0 0 1
+1 1 1
+2 1 1
+3 0 1
+0 0 2
+1 1 2
+2 1 2
+3 0 2
+0 0 3
+1 0 3
+2 0 3
+3 0 3
+
+3 3
+0 0 0
+0 0 0
+0 0 0
+0 0 0
+0 0 1
+1 1 1
+2 1 1
+3 0 1
+0 0 2
+1 1 2
+2 1 2
+3 0 2
+0 0 3
+1 0 3
+2 0 3
+3 0 3
+`
+
+func TestParsePatches(t *testing.T) {
+	patches, err := ParsePatches(strings.NewReader(twoPatches))
+
+	require.NoError(t, err)
+	require.Len(t, patches, 2)
+	points := patches[0].ControlPoints()
+	assert.Equal(t, tuple.NewPoint(0, 0, 0), points[0])
+	assert.Equal(t, tuple.NewPoint(1, 1, 1), points[5])
+	assert.Equal(t, tuple.NewPoint(3, 0, 3), points[15])
+	assert.Equal(t, tuple.NewPoint(0, 0, 0), patches[1].ControlPoints()[3])
+}
+
+func TestParseTessellatesEachPatchIntoAGroup(t *testing.T) {
+	parsed, err := Parse(strings.NewReader(twoPatches), 3)
+
+	require.NoError(t, err)
+	children := parsed.(*primitive.Group).Children()
+	require.Len(t, children, 2)
+	assert.Len(t, children[0].(*primitive.Group).Children(), 18)
+	assert.Len(t, children[1].(*primitive.Group).Children(), 15)
+}
+
+func TestParseErrors(t *testing.T) {
+	examples := []struct {
+		name, file, message string
+	}{
+		{"empty", "", "line 0: unexpected end of file"},
+		{"bad count", "x\n", "line 1: bad patch count"},
+		{"missing patch", "1\n", "line 1: expected 1 patches but found 0"},
+		{"huge count", "99999999999\n", "line 1: expected 99999999999 patches but found 0"},
+		{"not bicubic", "1\n2 3\n", "line 2: only bicubic patches are supported"},
+		{"short point", "1\n3 3\n0 0\n", "line 3: expected 3 coordinates"},
+		{"bad float", "1\n3 3\n0 0 z\n", "line 3: bad float"},
+		{"missing points", "1\n3 3\n0 0 0\n", "line 3: unexpected end of file"},
+	}
+
+	for _, e := range examples {
+		t.Run(e.name, func(t *testing.T) {
+			_, err := ParsePatches(strings.NewReader(e.file))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), e.message)
+		})
+	}
+}
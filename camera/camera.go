@@ -2,14 +2,15 @@ package camera
 
 import (
 	"image"
-	"math"
+	"math/rand"
 	"runtime"
 	"sync"
 
 	"github.com/danieltmartin/ray-tracer/canvas"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
 	"github.com/danieltmartin/ray-tracer/matrix"
 	"github.com/danieltmartin/ray-tracer/ray"
-	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/danieltmartin/ray-tracer/transform"
 	"github.com/danieltmartin/ray-tracer/world"
 )
 
@@ -18,56 +19,126 @@ const recursionDepth = 5
 type Camera struct {
 	hsize            uint
 	vsize            uint
-	fieldOfView      float64
+	projection       Projection
 	transform        matrix.Matrix
 	inverseTransform matrix.Matrix
 	pixelSize        float64
 	halfWidth        float64
 	halfHeight       float64
+	// motion replaces transform when the camera moves while the shutter is open.
+	motion         *transform.Motion
+	shutterOpen    float64
+	shutterClose   float64
+	shutterSamples int
+	// eyeOffset moves the camera sideways to one eye of a Stereo rig, whose eyes turn to meet
+	// at the convergence distance.
+	eyeOffset   float64
+	convergence float64
 }
 
+// New creates a camera with a Perspective projection that sees fieldOfView radians across the
+// wider side of the image.
 func New(hsize, vsize uint, fieldOfView float64) *Camera {
+	return NewWithProjection(hsize, vsize, NewPerspective(fieldOfView))
+}
+
+// NewWithProjection creates a camera that maps the image to rays with p.
+func NewWithProjection(hsize, vsize uint, p Projection) *Camera {
 	c := &Camera{
 		hsize:            hsize,
 		vsize:            vsize,
-		fieldOfView:      fieldOfView,
+		projection:       p,
 		transform:        matrix.Identity4(),
 		inverseTransform: matrix.Identity4(),
+		shutterSamples:   1,
 	}
 	computePixelSizeAndDimensions(c)
 	return c
 }
 
+func (c *Camera) Projection() Projection {
+	return c.projection
+}
+
+func (c *Camera) SetProjection(p Projection) {
+	c.projection = p
+	computePixelSizeAndDimensions(c)
+}
+
 func (c *Camera) SetTransform(t matrix.Matrix) {
 	c.transform = t
 	c.inverseTransform = t.Inverse()
+	c.motion = nil
+}
+
+// SetMotion makes the camera move, with view transforms given at each key.
+func (c *Camera) SetMotion(m transform.Motion) {
+	c.transform = m.Keys()[0].Matrix
+	c.inverseTransform = c.transform.Inverse()
+	c.motion = &m
+}
+
+// SetShutter sets the times the shutter opens and closes, and how many rays are traced per
+// pixel at times spread over that interval. Objects and cameras that move while the shutter
+// is open are blurred.
+func (c *Camera) SetShutter(open, close float64, samples int) {
+	if close < open {
+		panic("shutter can't close before it opens")
+	}
+	if samples < 1 {
+		panic("shutter needs at least one sample")
+	}
+	c.shutterOpen = open
+	c.shutterClose = close
+	c.shutterSamples = samples
 }
 
+// RayForPixel returns the ray through the center of a pixel when the shutter opens. It panics
+// for pixels the projection doesn't cover; use RayForPixelAt where there may be some.
 func (c *Camera) RayForPixel(px, py uint) ray.Ray {
+	r, ok := c.RayForPixelAt(px, py, c.shutterOpen)
+	if !ok {
+		panic("pixel is not covered by the camera's projection")
+	}
+	return r
+}
+
+// RayForPixelAt returns the ray through the center of a pixel at time. ok is false for pixels
+// the projection doesn't cover, such as the corners of a fisheye image.
+func (c *Camera) RayForPixelAt(px, py uint, time float64) (r ray.Ray, ok bool) {
 	// Offset from edge of canvas to center of pixel
 	xOffset := (float64(px) + 0.5) * c.pixelSize
 	yOffset := (float64(py) + 0.5) * c.pixelSize
 
-	untransformedPixel := tuple.NewPoint(
-		c.halfWidth-xOffset,
-		c.halfHeight-yOffset,
-		-1,
-	)
+	r, ok = c.projection.Ray(c.halfWidth-xOffset, c.halfHeight-yOffset)
+	if !ok {
+		return ray.Ray{}, false
+	}
+	if c.eyeOffset != 0 {
+		r = c.eyeRay(r)
+	}
 
-	// Transform the pixel to account for camera position.
+	// Transform the ray to account for camera position.
 	// We use the inverse because the transform matrix transforms the world, not the camera,
 	// so the inverse is the transform of the camera.
 	cameraTransform := c.inverseTransform
-	pixel := cameraTransform.MulTuple(untransformedPixel)
-	origin := cameraTransform.MulTuple(tuple.NewPoint(0, 0, 0))
-	direction := pixel.Sub(origin).Norm()
+	if c.motion != nil {
+		cameraTransform = c.motion.At(time).Inverse()
+	}
+	origin := cameraTransform.MulTuple(r.Origin())
+	direction := cameraTransform.MulTuple(r.Direction()).Norm()
 
-	return ray.New(origin, direction)
+	return ray.New(origin, direction).WithTime(time), true
 }
 
 func (c *Camera) Render(w *world.World) image.Image {
 	canvas := canvas.New(c.hsize, c.vsize)
+	c.renderInto(w, canvas, 0, 0)
+	return canvas
+}
 
+// renderInto renders the image into canvas with its top left corner at left, top.
+func (c *Camera) renderInto(w *world.World, canvas canvas.Canvas, left, top uint) {
 	semaphore := make(chan bool, runtime.NumCPU())
 	var wg sync.WaitGroup
 
@@ -78,21 +149,40 @@ func (c *Camera) Render(w *world.World) image.Image {
 		go func(y uint) {
 			defer wg.Done()
 			for x := uint(0); x < c.hsize; x++ {
-				r := c.RayForPixel(x, y)
-				color := w.ColorAt(r, recursionDepth)
-				canvas.WritePixel(x, y, color)
+				canvas.WritePixel(left+x, top+y, c.colorAt(w, x, y))
 			}
 			<-semaphore // Release
 		}(y)
 	}
 
 	wg.Wait()
+}
 
-	return canvas
+// colorAt traces the rays for a pixel. With more than one shutter sample, each is at a random
+// time within its own equal part of the shutter interval, so the samples cover it evenly.
+func (c *Camera) colorAt(w *world.World, x, y uint) floatcolor.Float64Color {
+	if c.shutterSamples == 1 {
+		r, ok := c.RayForPixelAt(x, y, c.shutterOpen)
+		if !ok {
+			return floatcolor.Black
+		}
+		return w.ColorAt(r, recursionDepth)
+	}
+	color := floatcolor.Black
+	duration := c.shutterClose - c.shutterOpen
+	for i := 0; i < c.shutterSamples; i++ {
+		time := c.shutterOpen + duration*(float64(i)+rand.Float64())/float64(c.shutterSamples)
+		r, ok := c.RayForPixelAt(x, y, time)
+		if !ok {
+			return floatcolor.Black
+		}
+		color = color.Add(w.ColorAt(r, recursionDepth))
+	}
+	return color.Mul(1 / float64(c.shutterSamples))
 }
 
 func computePixelSizeAndDimensions(c *Camera) {
-	halfView := math.Tan(c.fieldOfView / 2)
+	halfView := c.projection.HalfView()
 	aspectRatio := float64(c.hsize) / float64(c.vsize)
 	if aspectRatio >= 1 {
 		c.halfWidth = halfView
@@ -25,7 +25,7 @@ func TestConstructCamera(t *testing.T) {
 
 	assert.Equal(t, hsize, c.hsize)
 	assert.Equal(t, vsize, c.vsize)
-	assert.Equal(t, fieldOfView, c.fieldOfView)
+	assert.Equal(t, NewPerspective(fieldOfView), c.projection)
 	assert.Equal(t, matrix.Identity4(), c.transform)
 }
 
@@ -107,3 +107,55 @@ func testWorld() *world.World {
 
 	return w
 }
+
+func TestRayForPixelCarriesTime(t *testing.T) {
+	c := New(201, 101, math.Pi/2)
+	c.SetShutter(0.5, 1, 1)
+
+	assert.Equal(t, 0.5, c.RayForPixel(100, 50).Time())
+	r, ok := c.RayForPixelAt(100, 50, 0.75)
+	assert.True(t, ok)
+	assert.Equal(t, 0.75, r.Time())
+}
+
+func TestRayWhenCameraIsMoving(t *testing.T) {
+	c := New(201, 101, math.Pi/2)
+	c.SetMotion(transform.Between(transform.Translation(0, 0, 0), transform.Translation(-4, 0, 0)))
+
+	r, ok := c.RayForPixelAt(100, 50, 0.5)
+
+	assert.True(t, ok)
+	assert.True(t, tuple.NewPoint(2, 0, 0).Equals(r.Origin()))
+	assert.True(t, tuple.NewVector(0, 0, -1).Equals(r.Direction()))
+	assert.Equal(t, tuple.NewPoint(0, 0, 0), c.RayForPixel(100, 50).Origin())
+}
+
+func TestInvalidShutterPanics(t *testing.T) {
+	c := New(11, 11, math.Pi/2)
+
+	assert.Panics(t, func() { c.SetShutter(1, 0, 1) })
+	assert.Panics(t, func() { c.SetShutter(0, 1, 0) })
+}
+
+func TestRenderMotionBlur(t *testing.T) {
+	w := world.New()
+	l := light.NewPointLight(tuple.NewPoint(0, 0, -10), floatcolor.White)
+	w.AddLights(&l)
+	s := primitive.NewSphere()
+	s.SetMaterial(material.Default.WithAmbient(1).WithDiffuse(0).WithSpecular(0))
+	// The sphere sweeps across the center of the view while the shutter is open.
+	s.SetMotion(transform.Between(transform.Translation(-3, 0, 0), transform.Translation(3, 0, 0)))
+	w.AddPrimitives(&s)
+	c := New(11, 11, math.Pi/2)
+	c.SetTransform(transform.ViewTransform(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+
+	still := c.Render(w).At(5, 5).(floatcolor.Float64Color)
+	c.SetShutter(0, 1, 64)
+	blurred := c.Render(w).At(5, 5).(floatcolor.Float64Color)
+
+	// At the shutter opening the sphere is off to the side, and it covers the center for a third
+	// of the interval.
+	assert.Equal(t, floatcolor.Black, still)
+	r, _, _ := blurred.RGB()
+	assert.InDelta(t, 1.0/3, r, 0.1)
+}
@@ -0,0 +1,147 @@
+package camera
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Projection maps points on the image to rays leaving the camera. It works in camera space,
+// where the camera is at the origin looking toward -z with y up, before the camera transform
+// is applied.
+type Projection interface {
+	// HalfView returns half the extent of the wider side of the image, in the units of the
+	// points passed to Ray.
+	HalfView() float64
+	// Ray returns the ray through the point x, y on the image, measured from its center with x
+	// increasing to the left and y increasing upward. ok is false for points the projection
+	// doesn't cover, which are rendered black.
+	Ray(x, y float64) (r ray.Ray, ok bool)
+}
+
+// Perspective projects onto an image plane in front of the camera, like a pinhole camera,
+// so that straight lines stay straight and distant things look smaller.
+type Perspective struct {
+	fieldOfView float64
+}
+
+// NewPerspective creates a Perspective projection that sees fieldOfView radians across the
+// wider side of the image.
+func NewPerspective(fieldOfView float64) Perspective {
+	if fieldOfView <= 0 || fieldOfView >= math.Pi {
+		panic("perspective field of view must be between 0 and pi")
+	}
+	return Perspective{fieldOfView}
+}
+
+func (p Perspective) HalfView() float64 {
+	return math.Tan(p.fieldOfView / 2)
+}
+
+// Ray returns the ray through the point x, y on the image plane 1 unit in front of the camera.
+func (p Perspective) Ray(x, y float64) (ray.Ray, bool) {
+	return ray.New(tuple.NewPoint(0, 0, 0), tuple.NewVector(x, y, -1).Norm()), true
+}
+
+// Orthographic projects along parallel rays, so that things keep their size however far away
+// they are, as in technical drawings and isometric views.
+type Orthographic struct {
+	width float64
+}
+
+// NewOrthographic creates an Orthographic projection that sees width units across the wider
+// side of the image.
+func NewOrthographic(width float64) Orthographic {
+	if width <= 0 {
+		panic("orthographic width must be more than 0")
+	}
+	return Orthographic{width}
+}
+
+func (o Orthographic) HalfView() float64 {
+	return o.width / 2
+}
+
+// Ray returns the ray starting at x, y on the plane through the camera, facing forward.
+// Things behind that plane are not seen.
+func (o Orthographic) Ray(x, y float64) (ray.Ray, bool) {
+	return ray.New(tuple.NewPoint(x, y, 0), tuple.NewVector(0, 0, -1)), true
+}
+
+// FisheyeMapping is how the angle of a ray from the direction the camera faces maps to the
+// distance from the center of a fisheye image.
+type FisheyeMapping int
+
+const (
+	// Equidistant maps angles to distances in proportion, so angles are easy to measure.
+	Equidistant FisheyeMapping = iota
+	// Equisolid maps equal solid angles to equal areas, as most fisheye lenses do.
+	Equisolid
+)
+
+// Fisheye projects a hemisphere or more onto a disc, bending straight lines.
+type Fisheye struct {
+	fieldOfView float64
+	mapping     FisheyeMapping
+}
+
+// NewFisheye creates a Fisheye projection that sees fieldOfView radians, up to 2 pi, across
+// the wider side of the image. The image is a disc touching the sides of a square image, and
+// the area outside it is black.
+func NewFisheye(fieldOfView float64, mapping FisheyeMapping) Fisheye {
+	if fieldOfView <= 0 || fieldOfView > 2*math.Pi {
+		panic("fisheye field of view must be between 0 and 2 pi")
+	}
+	return Fisheye{fieldOfView, mapping}
+}
+
+// HalfView returns half the field of view, so points on the image are measured in radians
+// from the center, as an Equidistant fisheye maps them.
+func (f Fisheye) HalfView() float64 {
+	return f.fieldOfView / 2
+}
+
+func (f Fisheye) Ray(x, y float64) (ray.Ray, bool) {
+	r := math.Hypot(x, y)
+	if r > f.fieldOfView/2 {
+		return ray.Ray{}, false
+	}
+	theta := r
+	if f.mapping == Equisolid {
+		// r is proportional to sin(theta/2), and reaches the edge at half the field of view.
+		theta = 2 * math.Asin(r/(f.fieldOfView/2)*math.Sin(f.fieldOfView/4))
+	}
+	var dx, dy float64
+	if r > 0 {
+		dx, dy = x/r, y/r
+	}
+	sin := math.Sin(theta)
+	direction := tuple.NewVector(dx*sin, dy*sin, -math.Cos(theta))
+	return ray.New(tuple.NewPoint(0, 0, 0), direction), true
+}
+
+// Equirectangular projects the whole sphere around the camera onto an image twice as wide as
+// it is high, with longitude across and latitude up, as used for 360° panoramas and VR. The
+// center of the image is in front of the camera and the sides are behind it.
+type Equirectangular struct{}
+
+func NewEquirectangular() Equirectangular {
+	return Equirectangular{}
+}
+
+// HalfView returns pi, so points on the image are measured as longitude and latitude in
+// radians.
+func (e Equirectangular) HalfView() float64 {
+	return math.Pi
+}
+
+// Ray returns the ray at longitude x and latitude y. Images more than half as high as they are
+// wide reach past the poles, where they are not covered.
+func (e Equirectangular) Ray(x, y float64) (ray.Ray, bool) {
+	if math.Abs(y) > math.Pi/2 {
+		return ray.Ray{}, false
+	}
+	direction := tuple.NewVector(math.Sin(x)*math.Cos(y), math.Sin(y), -math.Cos(x)*math.Cos(y))
+	return ray.New(tuple.NewPoint(0, 0, 0), direction), true
+}
@@ -0,0 +1,112 @@
+package camera
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidProjectionsPanic(t *testing.T) {
+	assert.Panics(t, func() { NewPerspective(0) })
+	assert.Panics(t, func() { NewPerspective(math.Pi) })
+	assert.Panics(t, func() { NewOrthographic(0) })
+	assert.Panics(t, func() { NewFisheye(3*math.Pi, Equidistant) })
+}
+
+func TestOrthographicRaysAreParallel(t *testing.T) {
+	c := NewWithProjection(201, 101, NewOrthographic(4))
+
+	center := c.RayForPixel(100, 50)
+	corner := c.RayForPixel(0, 0)
+
+	test.AssertAlmost(t, tuple.NewPoint(0, 0, 0), center.Origin())
+	test.AssertAlmost(t, tuple.NewPoint(1.99005, 0.99502, 0), corner.Origin())
+	test.AssertAlmost(t, tuple.NewVector(0, 0, -1), center.Direction())
+	test.AssertAlmost(t, tuple.NewVector(0, 0, -1), corner.Direction())
+}
+
+func TestOrthographicRayWhenCameraIsTransformed(t *testing.T) {
+	c := NewWithProjection(201, 101, NewOrthographic(4))
+	c.SetTransform(transform.ViewTransform(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+
+	r := c.RayForPixel(0, 0)
+
+	test.AssertAlmost(t, tuple.NewPoint(-1.99005, 0.99502, -5), r.Origin())
+	test.AssertAlmost(t, tuple.NewVector(0, 0, 1), r.Direction())
+}
+
+func TestEquidistantFisheye(t *testing.T) {
+	c := NewWithProjection(101, 101, NewFisheye(math.Pi, Equidistant))
+
+	test.AssertAlmost(t, tuple.NewVector(0, 0, -1), c.RayForPixel(50, 50).Direction())
+	// Halfway to the edge is halfway to 90°.
+	test.AssertAlmost(t, tuple.NewVector(0, math.Sin(math.Pi/4), -math.Cos(math.Pi/4)), directionAt(c, 0, 0.5))
+	test.AssertAlmost(t, tuple.NewVector(1, 0, 0), directionAt(c, 1, 0))
+}
+
+func TestEquisolidFisheye(t *testing.T) {
+	c := NewWithProjection(101, 101, NewFisheye(math.Pi, Equisolid))
+
+	theta := 2 * math.Asin(0.5*math.Sin(math.Pi/4))
+	test.AssertAlmost(t, tuple.NewVector(0, math.Sin(theta), -math.Cos(theta)), directionAt(c, 0, 0.5))
+	test.AssertAlmost(t, tuple.NewVector(1, 0, 0), directionAt(c, 1, 0))
+}
+
+func TestFullCircleFisheyeSeesBehind(t *testing.T) {
+	c := NewWithProjection(101, 101, NewFisheye(2*math.Pi, Equidistant))
+
+	test.AssertAlmost(t, tuple.NewVector(0, 0, 1), directionAt(c, 0, 0.99999))
+}
+
+func TestFisheyeCornersAreBlack(t *testing.T) {
+	w := testWorld()
+	c := NewWithProjection(11, 11, NewFisheye(math.Pi, Equidistant))
+	c.SetTransform(transform.ViewTransform(tuple.NewPoint(0, 0, -1.5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+
+	image := c.Render(w)
+
+	_, ok := c.RayForPixelAt(0, 0, 0)
+	assert.False(t, ok)
+	assert.Panics(t, func() { c.RayForPixel(0, 0) })
+	assert.Equal(t, floatcolor.Black, image.At(0, 0))
+	assert.NotEqual(t, floatcolor.Black, image.At(5, 5))
+}
+
+func TestEquirectangular(t *testing.T) {
+	c := NewWithProjection(200, 100, NewEquirectangular())
+
+	test.AssertAlmost(t, tuple.NewVector(0, 0, -1), directionAt(c, 0, 0))
+	test.AssertAlmost(t, tuple.NewVector(1, 0, 0), directionAt(c, 0.5, 0))
+	test.AssertAlmost(t, tuple.NewVector(-1, 0, 0), directionAt(c, -0.5, 0))
+	test.AssertAlmost(t, tuple.NewVector(0, 0, 1), directionAt(c, 1, 0))
+	test.AssertAlmost(t, tuple.NewVector(0, 1, 0), directionAt(c, 0, 0.5))
+}
+
+func TestEquirectangularSeesAllAround(t *testing.T) {
+	w := testWorld()
+	c := NewWithProjection(40, 20, NewEquirectangular())
+	// Inside the sphere it fills the whole view.
+	c.SetTransform(transform.ViewTransform(tuple.NewPoint(0, 0, -0.75), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+
+	image := c.Render(w)
+
+	for _, p := range [][2]int{{0, 10}, {10, 10}, {20, 10}, {30, 10}, {39, 10}, {20, 0}, {20, 19}} {
+		assert.NotEqual(t, floatcolor.Black, image.At(p[0], p[1]), "%v", p)
+	}
+}
+
+// rayAt returns the direction of the ray through the point on the image at x and y from the
+// center, as fractions of the way from the center to the left and top of the wider side.
+func directionAt(c *Camera, x, y float64) tuple.Tuple {
+	halfView := c.projection.HalfView()
+	r, ok := c.projection.Ray(x*halfView, y*halfView)
+	if !ok {
+		return tuple.Tuple{}
+	}
+	return r.Transform(c.inverseTransform).Direction().Norm()
+}
@@ -0,0 +1,104 @@
+package camera
+
+import (
+	"image"
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/canvas"
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/danieltmartin/ray-tracer/world"
+)
+
+// StereoLayout is how a Stereo rig arranges the images of its two eyes in one image.
+type StereoLayout int
+
+const (
+	// SideBySide puts the left eye on the left and the right eye on the right.
+	SideBySide StereoLayout = iota
+	// TopBottom puts the left eye on top and the right eye below.
+	TopBottom
+)
+
+// Stereo is a pair of eyes either side of a camera, for viewing in VR headsets and other
+// stereoscopic displays. Each eye sees what the camera would, with its size, projection,
+// transform and shutter.
+//
+// With an Equirectangular projection the rig renders omni-directional stereo, where the eyes
+// circle the camera as they look around, as a head turning to face each direction would.
+type Stereo struct {
+	camera      *Camera
+	interocular float64
+	convergence float64
+	layout      StereoLayout
+}
+
+// NewStereo creates a Stereo rig around c with eyes interocular units apart, whose lines of
+// sight meet convergence units in front of the camera, where things appear at the depth of
+// the display. The distance is measured to a plane for flat projections and to a sphere for
+// Fisheye and Equirectangular ones. For eyes looking straight ahead, use math.Inf(1).
+func NewStereo(c *Camera, interocular, convergence float64, layout StereoLayout) *Stereo {
+	if interocular < 0 {
+		panic("interocular distance can't be less than 0")
+	}
+	if convergence <= 0 {
+		panic("convergence distance must be more than 0")
+	}
+	return &Stereo{c, interocular, convergence, layout}
+}
+
+// Eyes returns cameras for the left and right eyes as the rig is now.
+func (s *Stereo) Eyes() (left, right *Camera) {
+	return s.eye(s.interocular / 2), s.eye(-s.interocular / 2)
+}
+
+// eye returns a copy of the camera moved offset to the left.
+func (s *Stereo) eye(offset float64) *Camera {
+	eye := *s.camera
+	eye.eyeOffset = offset
+	eye.convergence = s.convergence
+	return &eye
+}
+
+// Render renders both eyes into one image laid out as the rig's layout says.
+func (s *Stereo) Render(w *world.World) image.Image {
+	left, right := s.Eyes()
+	hsize, vsize := s.camera.hsize, s.camera.vsize
+	if s.layout == TopBottom {
+		canvas := canvas.New(hsize, 2*vsize)
+		left.renderInto(w, canvas, 0, 0)
+		right.renderInto(w, canvas, 0, vsize)
+		return canvas
+	}
+	canvas := canvas.New(2*hsize, vsize)
+	left.renderInto(w, canvas, 0, 0)
+	right.renderInto(w, canvas, hsize, 0)
+	return canvas
+}
+
+// eyeRay moves r, in camera space, from the center of the camera to the eye, and turns it to
+// meet the center ray at the convergence distance.
+func (c *Camera) eyeRay(r ray.Ray) ray.Ray {
+	direction := r.Direction().Norm()
+	offset := tuple.NewVector(c.eyeOffset, 0, 0)
+	onSphere := false
+	switch c.projection.(type) {
+	case Equirectangular:
+		// The offset is to the left of the direction looked in, and shrinks toward the poles,
+		// where there is no left, so the eyes don't swap over at them.
+		offset = direction.Cross(tuple.NewVector(0, 1, 0)).Mul(c.eyeOffset)
+		onSphere = true
+	case Fisheye:
+		onSphere = true
+	}
+	origin := r.Origin().Add(offset)
+	if math.IsInf(c.convergence, 1) {
+		return ray.New(origin, direction)
+	}
+	distance := c.convergence
+	if !onSphere && direction.Z < 0 {
+		distance = c.convergence / -direction.Z
+	}
+	target := r.Origin().Add(direction.Mul(distance))
+	return ray.New(origin, target.Sub(origin).Norm())
+}
@@ -0,0 +1,109 @@
+package camera
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInvalidStereoPanics(t *testing.T) {
+	c := New(11, 11, math.Pi/2)
+
+	assert.Panics(t, func() { NewStereo(c, -1, 10, SideBySide) })
+	assert.Panics(t, func() { NewStereo(c, 0.1, 0, SideBySide) })
+}
+
+func TestStereoEyesConverge(t *testing.T) {
+	c := New(201, 101, math.Pi/2)
+	left, right := NewStereo(c, 0.2, 5, SideBySide).Eyes()
+
+	l := left.RayForPixel(100, 50)
+	r := right.RayForPixel(100, 50)
+
+	test.AssertAlmost(t, tuple.NewPoint(0.1, 0, 0), l.Origin())
+	test.AssertAlmost(t, tuple.NewPoint(-0.1, 0, 0), r.Origin())
+	test.AssertAlmost(t, tuple.NewPoint(0, 0, -5), l.Position(5/-l.Direction().Z))
+	test.AssertAlmost(t, tuple.NewPoint(0, 0, -5), r.Position(5/-r.Direction().Z))
+}
+
+func TestStereoEyesConvergeOnPlane(t *testing.T) {
+	c := New(201, 101, math.Pi/2)
+	left, right := NewStereo(c, 0.2, 5, SideBySide).Eyes()
+
+	l := left.RayForPixel(0, 0)
+	r := right.RayForPixel(0, 0)
+
+	test.AssertAlmost(t, r.Position(5/-r.Direction().Z), l.Position(5/-l.Direction().Z))
+}
+
+func TestParallelStereoEyes(t *testing.T) {
+	c := New(201, 101, math.Pi/2)
+	left, _ := NewStereo(c, 0.2, math.Inf(1), SideBySide).Eyes()
+
+	test.AssertAlmost(t, tuple.NewVector(0, 0, -1), left.RayForPixel(100, 50).Direction())
+}
+
+func TestStereoFollowsCameraTransform(t *testing.T) {
+	c := New(201, 101, math.Pi/2)
+	stereo := NewStereo(c, 0.2, 5, SideBySide)
+	c.SetTransform(transform.ViewTransform(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+	left, _ := stereo.Eyes()
+
+	r := left.RayForPixel(100, 50)
+
+	// Looking along +z, the left is -x.
+	test.AssertAlmost(t, tuple.NewPoint(-0.1, 0, -5), r.Origin())
+	test.AssertAlmost(t, tuple.NewPoint(0, 0, 0), r.Position(5/r.Direction().Z))
+}
+
+func TestOmnidirectionalStereo(t *testing.T) {
+	c := NewWithProjection(202, 101, NewEquirectangular())
+	left, right := NewStereo(c, 0.2, math.Inf(1), SideBySide).Eyes()
+
+	// A quarter of the way round, looking along +x, the left eye is behind the camera.
+	test.AssertAlmost(t, tuple.NewPoint(0, 0, 0.1), left.RayForPixel(50, 50).Origin())
+	test.AssertAlmost(t, tuple.NewPoint(0, 0, -0.1), right.RayForPixel(50, 50).Origin())
+	// Near the poles the eyes come together.
+	assert.Less(t, left.RayForPixel(100, 0).Origin().Sub(right.RayForPixel(100, 0).Origin()).Mag(), 0.01)
+}
+
+func TestRenderStereoLayouts(t *testing.T) {
+	w := testWorld()
+	c := New(11, 11, math.Pi/2)
+	c.SetTransform(transform.ViewTransform(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+	mono := c.Render(w)
+
+	sideBySide := NewStereo(c, 0, 5, SideBySide).Render(w)
+	topBottom := NewStereo(c, 0, 5, TopBottom).Render(w)
+
+	assert.Equal(t, image.Rect(0, 0, 22, 11), sideBySide.Bounds())
+	assert.Equal(t, image.Rect(0, 0, 11, 22), topBottom.Bounds())
+	// With the eyes together, both see what the camera sees.
+	assert.Equal(t, mono.At(5, 5), sideBySide.At(5, 5))
+	assert.Equal(t, mono.At(5, 5), sideBySide.At(16, 5))
+	assert.Equal(t, mono.At(5, 5), topBottom.At(5, 16))
+}
+
+func TestRenderStereoEyesSeeDifferently(t *testing.T) {
+	w := testWorld()
+	c := New(11, 11, math.Pi/2)
+	c.SetTransform(transform.ViewTransform(tuple.NewPoint(0, 0, -5), tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0)))
+
+	mono := c.Render(w)
+	image := NewStereo(c, 2, math.Inf(1), SideBySide).Render(w)
+
+	// Looking straight ahead, the sphere is to the right of the left eye and to the left of the
+	// right eye.
+	assert.Equal(t, floatcolor.Black, mono.At(3, 5))
+	assert.Equal(t, floatcolor.Black, mono.At(7, 5))
+	assert.Equal(t, floatcolor.Black, image.At(3, 5))
+	assert.NotEqual(t, floatcolor.Black, image.At(7, 5))
+	assert.NotEqual(t, floatcolor.Black, image.At(11+3, 5))
+	assert.Equal(t, floatcolor.Black, image.At(11+7, 5))
+}
@@ -0,0 +1,138 @@
+// Package environment provides backgrounds that give the color seen by rays that escape the
+// scene without hitting anything.
+package environment
+
+import (
+	"image"
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/image/texture"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Background gives the color of light arriving from infinitely far away along a direction.
+type Background interface {
+	// ColorAt returns the color seen looking along direction, which need not be normalized.
+	ColorAt(direction tuple.Tuple) floatcolor.Float64Color
+}
+
+// Constant is a background of a single color in every direction.
+type Constant floatcolor.Float64Color
+
+func (c Constant) ColorAt(direction tuple.Tuple) floatcolor.Float64Color {
+	return floatcolor.Float64Color(c)
+}
+
+// Gradient is a simple sky that blends from a horizon color up to a zenith color, with a
+// separate color for everything below the horizon.
+type Gradient struct {
+	ground, horizon, zenith floatcolor.Float64Color
+}
+
+func NewGradient(ground, horizon, zenith floatcolor.Float64Color) Gradient {
+	return Gradient{ground, horizon, zenith}
+}
+
+func (g Gradient) ColorAt(direction tuple.Tuple) floatcolor.Float64Color {
+	y := direction.Norm().Y
+	if y < 0 {
+		return g.ground
+	}
+	return g.horizon.Mul(1 - y).Add(g.zenith.Mul(y))
+}
+
+// Equirectangular is a background from a latitude-longitude panorama, such as an HDR photo of a
+// studio. The center of the image is seen looking along -z with +y up.
+type Equirectangular struct {
+	img image.Image
+	orientation
+}
+
+func NewEquirectangular(img image.Image) Equirectangular {
+	return Equirectangular{img, orientation(matrix.Identity4())}
+}
+
+// WithTransform rotates the environment by the given transformation.
+func (e Equirectangular) WithTransform(transform matrix.Matrix) Equirectangular {
+	return Equirectangular{e.img, orientation(transform.Inverse())}
+}
+
+func (e Equirectangular) ColorAt(direction tuple.Tuple) floatcolor.Float64Color {
+	d := e.toLocal(direction)
+	u := 0.5 + math.Atan2(d.X, -d.Z)/(2*math.Pi)
+	v := math.Acos(math.Max(-1, math.Min(1, d.Y))) / math.Pi
+	return texture.Bilinear(e.img, u, v, texture.Repeat, texture.Clamp)
+}
+
+// CubeFace identifies one of the six faces of a CubeMap.
+type CubeFace int
+
+const (
+	PositiveX CubeFace = iota
+	NegativeX
+	PositiveY
+	NegativeY
+	PositiveZ
+	NegativeZ
+)
+
+// CubeMap is a background made from six square images, one for each face of a cube surrounding
+// the scene, laid out using the same conventions as OpenGL cube maps.
+type CubeMap struct {
+	faces [6]image.Image
+	orientation
+}
+
+// NewCubeMap creates a cube map from images indexed by CubeFace.
+func NewCubeMap(faces [6]image.Image) CubeMap {
+	return CubeMap{faces, orientation(matrix.Identity4())}
+}
+
+// WithTransform rotates the environment by the given transformation.
+func (c CubeMap) WithTransform(transform matrix.Matrix) CubeMap {
+	return CubeMap{c.faces, orientation(transform.Inverse())}
+}
+
+func (c CubeMap) ColorAt(direction tuple.Tuple) floatcolor.Float64Color {
+	d := c.toLocal(direction)
+	absX, absY, absZ := math.Abs(d.X), math.Abs(d.Y), math.Abs(d.Z)
+
+	// Project onto the face of the dominant axis, giving coordinates from -1 to 1 across it.
+	var face CubeFace
+	var sc, tc, major float64
+	switch {
+	case absX >= absY && absX >= absZ:
+		major = absX
+		if d.X > 0 {
+			face, sc, tc = PositiveX, -d.Z, -d.Y
+		} else {
+			face, sc, tc = NegativeX, d.Z, -d.Y
+		}
+	case absY >= absZ:
+		major = absY
+		if d.Y > 0 {
+			face, sc, tc = PositiveY, d.X, d.Z
+		} else {
+			face, sc, tc = NegativeY, d.X, -d.Z
+		}
+	default:
+		major = absZ
+		if d.Z > 0 {
+			face, sc, tc = PositiveZ, d.X, -d.Y
+		} else {
+			face, sc, tc = NegativeZ, -d.X, -d.Y
+		}
+	}
+
+	u := (sc/major + 1) / 2
+	v := (tc/major + 1) / 2
+	return texture.Bilinear(c.faces[face], u, v, texture.Clamp, texture.Clamp)
+}
+
+type orientation matrix.Matrix
+
+func (o orientation) toLocal(direction tuple.Tuple) tuple.Tuple {
+	return matrix.Matrix(o).MulTuple(direction).Norm()
+}
@@ -0,0 +1,115 @@
+package environment
+
+import (
+	"image"
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/canvas"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConstantIsTheSameInEveryDirection(t *testing.T) {
+	c := Constant(floatcolor.New(0.2, 0.3, 0.4))
+
+	assert.Equal(t, floatcolor.New(0.2, 0.3, 0.4), c.ColorAt(tuple.NewVector(0, 1, 0)))
+	assert.Equal(t, floatcolor.New(0.2, 0.3, 0.4), c.ColorAt(tuple.NewVector(1, -1, 0)))
+}
+
+func TestGradientBlendsFromHorizonToZenith(t *testing.T) {
+	g := NewGradient(floatcolor.Red, floatcolor.Green, floatcolor.Blue)
+
+	test.AssertAlmost(t, floatcolor.Blue, g.ColorAt(tuple.NewVector(0, 1, 0)))
+	test.AssertAlmost(t, floatcolor.Green, g.ColorAt(tuple.NewVector(1, 0, 0)))
+	test.AssertAlmost(t, floatcolor.New(0, 0.5, 0.5), g.ColorAt(tuple.NewVector(0, 0.5, math.Sqrt(3)/2)))
+	test.AssertAlmost(t, floatcolor.Red, g.ColorAt(tuple.NewVector(0, -1, 1)))
+}
+
+func TestEquirectangularMapsLatitudeToRows(t *testing.T) {
+	img := canvas.New(4, 2)
+	for x := uint(0); x < 4; x++ {
+		img.WritePixel(x, 0, floatcolor.Red)
+		img.WritePixel(x, 1, floatcolor.Blue)
+	}
+	e := NewEquirectangular(img)
+
+	test.AssertAlmost(t, floatcolor.Red, e.ColorAt(tuple.NewVector(0, 1, 0)))
+	test.AssertAlmost(t, floatcolor.Blue, e.ColorAt(tuple.NewVector(0, -1, 0)))
+	test.AssertAlmost(t, floatcolor.New(0.5, 0, 0.5), e.ColorAt(tuple.NewVector(0, 0, -1)))
+}
+
+func TestEquirectangularCentersImageAlongNegativeZ(t *testing.T) {
+	img := canvas.New(4, 1)
+	img.WritePixel(1, 0, floatcolor.Red)
+	img.WritePixel(2, 0, floatcolor.Red)
+	e := NewEquirectangular(img)
+
+	test.AssertAlmost(t, floatcolor.Red, e.ColorAt(tuple.NewVector(0, 0, -1)))
+	test.AssertAlmost(t, floatcolor.Black, e.ColorAt(tuple.NewVector(0, 0, 1)))
+}
+
+func TestEquirectangularWithTransform(t *testing.T) {
+	img := canvas.New(4, 1)
+	img.WritePixel(1, 0, floatcolor.Red)
+	img.WritePixel(2, 0, floatcolor.Red)
+	e := NewEquirectangular(img).WithTransform(transform.RotationY(math.Pi))
+
+	test.AssertAlmost(t, floatcolor.Black, e.ColorAt(tuple.NewVector(0, 0, -1)))
+	test.AssertAlmost(t, floatcolor.Red, e.ColorAt(tuple.NewVector(0, 0, 1)))
+}
+
+func TestCubeMapSelectsFaceByDominantAxis(t *testing.T) {
+	colors := [6]floatcolor.Float64Color{
+		PositiveX: floatcolor.New(1, 0, 0),
+		NegativeX: floatcolor.New(0.5, 0, 0),
+		PositiveY: floatcolor.New(0, 1, 0),
+		NegativeY: floatcolor.New(0, 0.5, 0),
+		PositiveZ: floatcolor.New(0, 0, 1),
+		NegativeZ: floatcolor.New(0, 0, 0.5),
+	}
+	var faces [6]image.Image
+	for i, c := range colors {
+		img := canvas.New(2, 2)
+		for y := uint(0); y < 2; y++ {
+			for x := uint(0); x < 2; x++ {
+				img.WritePixel(x, y, c)
+			}
+		}
+		faces[i] = img
+	}
+	c := NewCubeMap(faces)
+
+	tests := []struct {
+		direction tuple.Tuple
+		face      CubeFace
+	}{
+		{tuple.NewVector(1, 0.2, 0.3), PositiveX},
+		{tuple.NewVector(-1, 0.2, 0.3), NegativeX},
+		{tuple.NewVector(0.2, 1, 0.3), PositiveY},
+		{tuple.NewVector(0.2, -1, 0.3), NegativeY},
+		{tuple.NewVector(0.2, 0.3, 1), PositiveZ},
+		{tuple.NewVector(0.2, 0.3, -1), NegativeZ},
+	}
+	for _, tt := range tests {
+		test.AssertAlmost(t, colors[tt.face], c.ColorAt(tt.direction))
+	}
+}
+
+func TestCubeMapOrientsFaceImages(t *testing.T) {
+	var faces [6]image.Image
+	for i := range faces {
+		faces[i] = canvas.New(2, 2)
+	}
+	front := canvas.New(2, 2)
+	// OpenGL cube maps place the top left of the -z face towards +x and +y.
+	front.WritePixel(0, 0, floatcolor.Red)
+	faces[NegativeZ] = front
+	c := NewCubeMap(faces)
+
+	test.AssertAlmost(t, floatcolor.Red, c.ColorAt(tuple.NewVector(0.9, 0.9, -1)))
+	test.AssertAlmost(t, floatcolor.Black, c.ColorAt(tuple.NewVector(-0.9, 0.9, -1)))
+}
@@ -0,0 +1,178 @@
+package environment
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/light"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// skyLuminanceScale converts the model's luminance in kcd/m² to the scene's units, making a
+// clear midday sky around a tenth as bright as its sun.
+const skyLuminanceScale = 0.01
+
+// minSkyElevation is the cosine of the angle from the zenith below which the sky is not
+// evaluated.
+const minSkyElevation = 0.01
+
+// Sky is a clear daylight sky following the Preetham model, whose color depends on the position
+// of the sun and the turbidity (haziness) of the atmosphere. Use it as a World background with
+// environment lighting enabled for ambient light from the sky, and add Sun as a light to match.
+//
+// Directions below the horizon see the sky just above the horizon.
+type Sky struct {
+	sun        tuple.Tuple
+	sunTheta   float64
+	intensity  float64
+	luminance  perez
+	chromaX    perez
+	chromaY    perez
+	zenithLum  float64
+	zenithX    float64
+	zenithY    float64
+	sunSpectra floatcolor.Float64Color
+}
+
+// NewSky creates a sky with the sun at the given elevation above the horizon and azimuth,
+// both in radians. An azimuth of 0 puts the sun towards +z, increasing towards +x. Turbidity
+// ranges from 2 for a very clear sky to 10 or more for a hazy one.
+func NewSky(elevation, azimuth, turbidity float64) Sky {
+	if turbidity < 1 {
+		panic("sky turbidity must be at least 1")
+	}
+	sun := tuple.NewVector(
+		math.Cos(elevation)*math.Sin(azimuth),
+		math.Sin(elevation),
+		math.Cos(elevation)*math.Cos(azimuth))
+	theta := math.Pi/2 - math.Max(elevation, 0)
+	t := turbidity
+
+	s := Sky{
+		sun:       sun,
+		sunTheta:  theta,
+		intensity: 1,
+		luminance: perez{0.1787*t - 1.4630, -0.3554*t + 0.4275, -0.0227*t + 5.3251, 0.1206*t - 2.5771, -0.0670*t + 0.3703},
+		chromaX:   perez{-0.0193*t - 0.2592, -0.0665*t + 0.0008, -0.0004*t + 0.2125, -0.0641*t - 0.8989, -0.0033*t + 0.0452},
+		chromaY:   perez{-0.0167*t - 0.2608, -0.0950*t + 0.0092, -0.0079*t + 0.2102, -0.0441*t - 1.6537, -0.0109*t + 0.0529},
+	}
+
+	chi := (4.0/9 - t/120) * (math.Pi - 2*theta)
+	s.zenithLum = (4.0453*t-4.9710)*math.Tan(chi) - 0.2155*t + 2.4192
+	s.zenithX = zenithChromaticity(t, theta, [3][4]float64{
+		{0.00166, -0.00375, 0.00209, 0},
+		{-0.02903, 0.06377, -0.03202, 0.00394},
+		{0.11693, -0.21196, 0.06052, 0.25886},
+	})
+	s.zenithY = zenithChromaticity(t, theta, [3][4]float64{
+		{0.00275, -0.00610, 0.00317, 0},
+		{-0.04214, 0.08970, -0.04153, 0.00516},
+		{0.15346, -0.26756, 0.06670, 0.26688},
+	})
+	s.sunSpectra = sunTransmittance(theta, t)
+	return s
+}
+
+// WithIntensity scales the brightness of the sky and its sun.
+func (s Sky) WithIntensity(intensity float64) Sky {
+	s.intensity = intensity
+	return s
+}
+
+// SunDirection returns the unit vector pointing towards the sun.
+func (s Sky) SunDirection() tuple.Tuple {
+	return s.sun
+}
+
+// SunColor returns the color of sunlight after passing through the atmosphere, which reddens
+// as the sun nears the horizon or the air becomes hazier.
+func (s Sky) SunColor() floatcolor.Float64Color {
+	return s.sunSpectra.Mul(s.intensity)
+}
+
+// Sun returns a directional light matching the sun in the sky.
+func (s Sky) Sun() light.PointLight {
+	return light.NewDirectionalLight(s.sun.Neg(), s.SunColor())
+}
+
+func (s Sky) ColorAt(direction tuple.Tuple) floatcolor.Float64Color {
+	d := direction.Norm()
+	if d.Y < minSkyElevation {
+		// Look just above the horizon instead, where the model's 1/cos(theta) term is well
+		// behaved.
+		horizontal := tuple.NewVector(d.X, 0, d.Z)
+		if horizontal.Mag() == 0 {
+			horizontal = tuple.NewVector(0, 0, 1)
+		}
+		d = horizontal.Norm().Mul(math.Sqrt(1 - minSkyElevation*minSkyElevation))
+		d.Y = minSkyElevation
+	}
+	theta := math.Acos(d.Y)
+	gamma := math.Acos(math.Max(-1, math.Min(1, d.Dot(s.sun))))
+
+	lum := s.zenithLum * s.luminance.relative(theta, gamma, s.sunTheta)
+	x := s.zenithX * s.chromaX.relative(theta, gamma, s.sunTheta)
+	y := s.zenithY * s.chromaY.relative(theta, gamma, s.sunTheta)
+	return xyYToRGB(x, y, lum*skyLuminanceScale*s.intensity)
+}
+
+// perez holds the coefficients of the Perez sky distribution function.
+type perez struct {
+	a, b, c, d, e float64
+}
+
+func (p perez) at(theta, gamma float64) float64 {
+	cosGamma := math.Cos(gamma)
+	return (1 + p.a*math.Exp(p.b/math.Cos(theta))) *
+		(1 + p.c*math.Exp(p.d*gamma) + p.e*cosGamma*cosGamma)
+}
+
+// relative returns the distribution in the direction theta from the zenith and gamma from the
+// sun, relative to its value at the zenith.
+func (p perez) relative(theta, gamma, sunTheta float64) float64 {
+	return p.at(theta, gamma) / p.at(0, sunTheta)
+}
+
+func zenithChromaticity(turbidity, sunTheta float64, m [3][4]float64) float64 {
+	t := [3]float64{turbidity * turbidity, turbidity, 1}
+	th := [4]float64{sunTheta * sunTheta * sunTheta, sunTheta * sunTheta, sunTheta, 1}
+	sum := 0.0
+	for i := range t {
+		for j := range th {
+			sum += t[i] * m[i][j] * th[j]
+		}
+	}
+	return sum
+}
+
+// xyYToRGB converts CIE xyY to linear sRGB.
+func xyYToRGB(x, y, lum float64) floatcolor.Float64Color {
+	if y <= 0 {
+		return floatcolor.Black
+	}
+	cx := x / y * lum
+	cz := (1 - x - y) / y * lum
+	return floatcolor.New(
+		math.Max(0, 3.2406*cx-1.5372*lum-0.4986*cz),
+		math.Max(0, -0.9689*cx+1.8758*lum+0.0415*cz),
+		math.Max(0, 0.0557*cx-0.2040*lum+1.0570*cz))
+}
+
+// sunTransmittance approximates the fraction of red, green and blue sunlight that reaches the
+// ground through Rayleigh and aerosol scattering, using the formulae from Preetham's appendix.
+func sunTransmittance(sunTheta, turbidity float64) floatcolor.Float64Color {
+	degrees := sunTheta * 180 / math.Pi
+	if degrees >= 93 {
+		return floatcolor.Black
+	}
+	// Relative optical mass of the air the light passes through.
+	mass := 1 / (math.Cos(sunTheta) + 0.15*math.Pow(93.885-degrees, -1.253))
+	beta := 0.04608*turbidity - 0.04586
+
+	transmittance := func(micrometres float64) float64 {
+		rayleigh := math.Exp(-0.008735 * math.Pow(micrometres, -4.08) * mass)
+		aerosol := math.Exp(-beta * math.Pow(micrometres, -1.3) * mass)
+		return rayleigh * aerosol
+	}
+	return floatcolor.New(transmittance(0.65), transmittance(0.57), transmittance(0.475))
+}
@@ -0,0 +1,82 @@
+package environment
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSkyPositionsSun(t *testing.T) {
+	s := NewSky(math.Pi/6, math.Pi/2, 3)
+
+	test.AssertAlmost(t, tuple.NewVector(math.Sqrt(3)/2, 0.5, 0), s.SunDirection())
+}
+
+func TestMiddaySkyIsBlueAtZenith(t *testing.T) {
+	s := NewSky(math.Pi/3, 0, 3)
+
+	r, g, b := s.ColorAt(tuple.NewVector(0, 1, 0)).RGB()
+
+	assert.Greater(t, b, g)
+	assert.Greater(t, g, r)
+}
+
+func TestSkyIsBrighterNearSun(t *testing.T) {
+	s := NewSky(math.Pi/6, 0, 3)
+
+	_, toward, _ := s.ColorAt(tuple.NewVector(0, 0.6, 1)).RGB()
+	_, away, _ := s.ColorAt(tuple.NewVector(0, 0.6, -1)).RGB()
+
+	assert.Greater(t, toward, away)
+}
+
+func TestSkyBelowHorizonMatchesHorizon(t *testing.T) {
+	s := NewSky(math.Pi/4, 0, 3)
+
+	test.AssertAlmost(t, s.ColorAt(tuple.NewVector(1, 0, 0)), s.ColorAt(tuple.NewVector(1, -1, 0)))
+}
+
+func TestSunReddensTowardsHorizon(t *testing.T) {
+	highR, highG, highB := NewSky(math.Pi/3, 0, 3).SunColor().RGB()
+	lowR, lowG, lowB := NewSky(math.Pi/36, 0, 3).SunColor().RGB()
+
+	assert.Less(t, lowB/lowR, highB/highR)
+	assert.Less(t, lowG, highG)
+}
+
+func TestHazierSkyDimsSun(t *testing.T) {
+	_, clear, _ := NewSky(math.Pi/4, 0, 2).SunColor().RGB()
+	_, hazy, _ := NewSky(math.Pi/4, 0, 8).SunColor().RGB()
+
+	assert.Less(t, hazy, clear)
+}
+
+func TestSkyWithIntensity(t *testing.T) {
+	s := NewSky(math.Pi/4, 0, 3)
+	bright := s.WithIntensity(2)
+	d := tuple.NewVector(0.3, 0.5, 0.2)
+
+	test.AssertAlmost(t, s.ColorAt(d).Mul(2), bright.ColorAt(d))
+	test.AssertAlmost(t, s.SunColor().Mul(2), bright.SunColor())
+}
+
+func TestSunLightShinesFromSun(t *testing.T) {
+	s := NewSky(math.Pi/4, math.Pi, 3)
+
+	l := s.Sun()
+
+	assert.True(t, l.IsDirectional())
+	test.AssertAlmost(t, s.SunDirection(), l.DirectionFrom(tuple.NewPoint(5, 0, 3)))
+	assert.Equal(t, s.SunColor(), l.Intensity())
+}
+
+func TestSkyStraightDownIsFinite(t *testing.T) {
+	s := NewSky(math.Pi/4, 0, 3)
+
+	r, g, b := s.ColorAt(tuple.NewVector(0, -1, 0)).RGB()
+
+	assert.False(t, math.IsNaN(r) || math.IsNaN(g) || math.IsNaN(b))
+}
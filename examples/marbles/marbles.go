@@ -58,6 +58,8 @@ func main() {
 
 	rand.Seed(0)
 
+	// Every marble is an instance of the same sphere with its own transform and material.
+	sphere := primitive.NewSphere()
 	var primitives []primitive.Primitive
 	length := 16.0
 	spacing := 3.0
@@ -68,20 +70,25 @@ func main() {
 			zTrans := z*spacing + spacing*rand.Float64()
 			color1 := uint32(rand.Int31())
 			color2 := uint32(rand.Int31())
-			s := primitive.NewSphere()
+			s := primitive.NewInstance(&sphere)
 			s.SetTransform(transform.Identity().
 				Scaling(scale, scale, scale).
 				Translation(xTrans, 1+scale/2, zTrans).
 				Matrix())
+			veinAngle := rand.Float64() * math.Pi
 			s.SetMaterial(material.Default.
-				WithPattern(material.NewGradientPattern(
-					floatcolor.NewFromInt(color1), floatcolor.NewFromInt(color2)).
-					WithTransform(transform.RotationY(math.Pi / 8))).
+				WithPattern(material.NewMarblePattern(
+					floatcolor.NewFromInt(color1), floatcolor.NewFromInt(color2), 1.5).
+					WithTransform(transform.Identity().
+						Scaling(0.3, 0.3, 0.3).
+						RotationZ(veinAngle).
+						RotationY(veinAngle).
+						Matrix())).
 				WithDiffuse(0.7).
 				WithReflective(0.2).
 				WithSpecular(0.8))
 
-			primitives = append(primitives, &s)
+			primitives = append(primitives, s)
 		}
 	}
 
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"math"
+	"time"
+
+	"github.com/danieltmartin/ray-tracer/animation"
+	"github.com/danieltmartin/ray-tracer/camera"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/light"
+	"github.com/danieltmartin/ray-tracer/material"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/primitive"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/danieltmartin/ray-tracer/world"
+)
+
+var first = flag.Int("first", 0, "first `frame` to render")
+var last = flag.Int("last", 47, "last `frame` to render")
+var frames = flag.Int("frames", 48, "number of frames in a full turn")
+var out = flag.String("out", "turntable%04d.png", "output file `pattern`, formatted with the frame number")
+var width = flag.Uint("width", 640, "image width in pixels")
+var height = flag.Uint("height", 360, "image height in pixels")
+var shutter = flag.Float64("shutter", 0, "`frames` the shutter stays open for, to blur movement")
+var samples = flag.Int("samples", 8, "rays per pixel when the shutter is open")
+
+func main() {
+	flag.Parse()
+
+	floor := primitive.NewPlane()
+	floor.SetMaterial(floor.Material().
+		WithPattern(material.NewCheckerPattern(
+			floatcolor.NewFromInt(0xded3d3), floatcolor.Black)).
+		WithSpecular(0).
+		WithReflective(0.1),
+	)
+
+	sphere := primitive.NewSphere()
+	sphere.SetTransform(transform.Translation(0, 1, 0))
+	sphere.SetMaterial(material.Default.
+		WithColor(floatcolor.NewFromInt(0x9f45ff)).
+		WithDiffuse(0.7).
+		WithReflective(0.2).
+		WithSpecular(0.8))
+
+	cube := primitive.NewCube()
+	cube.SetMaterial(material.Default.
+		WithColor(floatcolor.NewFromInt(0xa8eb12)).
+		WithDiffuse(0.7).
+		WithSpecular(0.3))
+
+	cone := primitive.NewCone(-1, 0, true)
+	cone.SetTransform(transform.Identity().
+		Scaling(0.5, 1.5, 0.5).
+		Translation(-2, 1.5, 1).
+		Matrix())
+	cone.SetMaterial(material.Default.
+		WithColor(floatcolor.NewFromInt(0x051937)).
+		WithDiffuse(0.7).
+		WithReflective(0.3))
+
+	light := light.NewPointLight(tuple.NewPoint(-10, 10, -10), floatcolor.White)
+
+	world := world.New()
+	world.AddPrimitives(&floor, &sphere, &cube, &cone)
+	world.AddLights(&light)
+
+	camera := camera.New(*width, *height, math.Pi/3)
+	if *shutter > 0 {
+		camera.SetShutter(0, 1, *samples)
+	}
+
+	anim := animation.New()
+	anim.SetShutter(*shutter)
+
+	// The camera circles the scene once every frames, so the track runs past the last frame.
+	// Keys a quarter turn apart are close enough for the track to turn the right way between
+	// them, and since the camera only turns about the y axis it stays on the circle.
+	turn := float64(*frames)
+	var orbit []animation.Key[matrix.Matrix]
+	for i := 0; i <= 4; i++ {
+		orbit = append(orbit, animation.Key[matrix.Matrix]{Frame: turn * float64(i) / 4, Value: viewAt(float64(i) * math.Pi / 2)})
+	}
+	anim.CameraTransform(camera, animation.NewTransformTrack(orbit...))
+
+	// The cube bounces in the middle of the turn.
+	anim.Transform(&cube, animation.NewMatrixTrack(
+		animation.Key[matrix.Matrix]{Frame: 0, Value: cubeAt(0), Curve: animation.EaseOut},
+		animation.Key[matrix.Matrix]{Frame: turn / 2, Value: cubeAt(1.5), Curve: animation.EaseIn},
+		animation.Key[matrix.Matrix]{Frame: turn, Value: cubeAt(0)},
+	))
+
+	// The light warms up over the turn.
+	anim.LightIntensity(&light, animation.NewColorTrack(
+		animation.Key[floatcolor.Float64Color]{Frame: 0, Value: floatcolor.White},
+		animation.Key[floatcolor.Float64Color]{Frame: turn, Value: floatcolor.New(1.2, 0.9, 0.6)},
+	))
+
+	start := time.Now()
+	if err := animation.RenderFrames(anim, camera, world, *first, *last, *out); err != nil {
+		log.Fatal(err)
+	}
+	log.Printf("Render time: %v\n", time.Since(start))
+
+	world.Stats().Log()
+}
+
+// viewAt returns the view from angle radians round the circle the camera follows.
+// ViewTransform shrinks the view across and up by the sine of the angle between the direction
+// looked in and up. Undoing that leaves only a rotation and translation, which the track
+// interpolates exactly.
+func viewAt(angle float64) matrix.Matrix {
+	from := tuple.NewPoint(6*math.Sin(angle), 2.5, -6*math.Cos(angle))
+	to, up := tuple.NewPoint(0, 1, 0), tuple.NewVector(0, 1, 0)
+	s := 1 / to.Sub(from).Norm().Cross(up).Mag()
+	return transform.Scaling(s, s, 1).Mul(transform.ViewTransform(from, to, up))
+}
+
+func cubeAt(height float64) matrix.Matrix {
+	return transform.Identity().
+		Scaling(0.5, 0.5, 0.5).
+		RotationY(math.Pi/5).
+		Translation(2, 0.5+height, -0.5).
+		Matrix()
+}
@@ -150,7 +150,7 @@ func main() {
 	}
 
 	start := time.Now()
-	teapot, err := obj.Parse(file)
+	teapot, err := obj.ParseSubdivided(file, 2)
 	duration := time.Since(start)
 	log.Printf("Obj parse time: %v\n", duration)
 	if err != nil {
@@ -83,11 +83,12 @@ func float64Model(c color.Color) color.Color {
 	if _, ok := c.(Float64Color); ok {
 		return c
 	}
+	// RGBA returns 16 bit values in 32 bit integers
 	r, g, b, _ := c.RGBA()
 	return New(
-		float64(r)/math.MaxUint32,
-		float64(g)/math.MaxUint32,
-		float64(b)/math.MaxUint32,
+		float64(r)/math.MaxUint16,
+		float64(g)/math.MaxUint16,
+		float64(b)/math.MaxUint16,
 	)
 }
 
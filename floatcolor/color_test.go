@@ -1,6 +1,7 @@
 package floatcolor
 
 import (
+	"image/color"
 	"math"
 	"testing"
 
@@ -62,6 +63,18 @@ func TestRGBAClamp(t *testing.T) {
 	assert.Equal(t, uint32(math.MaxUint16), a)
 }
 
+func TestModelConvertsOtherColors(t *testing.T) {
+	c := Float64Model.Convert(color.RGBA{0xFF, 0x00, 0x80, 0xFF})
+
+	assertAlmost(t, New(1, 0, float64(0x8080)/math.MaxUint16), c.(Float64Color))
+}
+
+func TestModelLeavesFloat64ColorsUnchanged(t *testing.T) {
+	c := New(2, 3, 4)
+
+	assert.Equal(t, c, Float64Model.Convert(c))
+}
+
 func assertAlmost(t *testing.T, c1 Float64Color, c2 Float64Color) {
 	r1, g1, b1 := c1.RGB()
 	r2, g2, b2 := c2.RGB()
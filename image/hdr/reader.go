@@ -0,0 +1,171 @@
+// Package hdr reads and writes images in the Radiance RGBE (.hdr) format, which stores colors
+// outside the 0 to 1 range and is commonly used for environment maps.
+package hdr
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"io"
+	"math"
+	"strings"
+
+	"github.com/danieltmartin/ray-tracer/canvas"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+)
+
+// maxPixels is the most pixels Decode will read, which is enough for a 16K by 4K panorama.
+// It stops a corrupt header from asking for more memory than any real image needs.
+const maxPixels = 1 << 26
+
+func init() {
+	image.RegisterFormat("hdr", "#?", Decode, DecodeConfig)
+}
+
+// Decode reads a Radiance RGBE image. The returned image is a canvas.Canvas whose pixels are
+// floatcolor.Float64Color values that may exceed 1.
+func Decode(r io.Reader) (image.Image, error) {
+	br := bufio.NewReader(r)
+	width, height, err := readHeader(br)
+	if err != nil {
+		return nil, err
+	}
+
+	c := canvas.New(uint(width), uint(height))
+	scanline := make([][4]byte, width)
+	for y := 0; y < height; y++ {
+		if err := readScanline(br, scanline); err != nil {
+			return nil, fmt.Errorf("scanline %v: %v", y, err)
+		}
+		for x, rgbe := range scanline {
+			c.WritePixel(uint(x), uint(y), fromRGBE(rgbe))
+		}
+	}
+
+	return c, nil
+}
+
+// DecodeConfig returns the dimensions of a Radiance RGBE image without decoding the pixels.
+func DecodeConfig(r io.Reader) (image.Config, error) {
+	width, height, err := readHeader(bufio.NewReader(r))
+	if err != nil {
+		return image.Config{}, err
+	}
+	return image.Config{ColorModel: floatcolor.Float64Model, Width: width, Height: height}, nil
+}
+
+func readHeader(r *bufio.Reader) (int, int, error) {
+	magic, err := r.ReadString('\n')
+	if err != nil {
+		return 0, 0, fmt.Errorf("reading header: %v", err)
+	}
+	if !strings.HasPrefix(magic, "#?") {
+		return 0, 0, fmt.Errorf("not a radiance image")
+	}
+
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			return 0, 0, fmt.Errorf("reading header: %v", err)
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break
+		}
+		if strings.HasPrefix(line, "FORMAT=") && line != "FORMAT=32-bit_rle_rgbe" {
+			return 0, 0, fmt.Errorf("unsupported format %v", strings.TrimPrefix(line, "FORMAT="))
+		}
+	}
+
+	resolution, err := r.ReadString('\n')
+	if err != nil {
+		return 0, 0, fmt.Errorf("reading resolution: %v", err)
+	}
+	var width, height int
+	if _, err := fmt.Sscanf(resolution, "-Y %d +X %d", &height, &width); err != nil {
+		return 0, 0, fmt.Errorf("unsupported resolution %q", strings.TrimSpace(resolution))
+	}
+	if width <= 0 || height <= 0 {
+		return 0, 0, fmt.Errorf("invalid resolution %vx%v", width, height)
+	}
+	if width > maxPixels || height > maxPixels || width*height > maxPixels {
+		return 0, 0, fmt.Errorf("resolution %vx%v is too large", width, height)
+	}
+
+	return width, height, nil
+}
+
+func readScanline(r *bufio.Reader, scanline [][4]byte) error {
+	var first [4]byte
+	if _, err := io.ReadFull(r, first[:]); err != nil {
+		return err
+	}
+
+	width := len(scanline)
+	isRunLengthEncoded := width >= 8 && width <= 0x7fff &&
+		first[0] == 2 && first[1] == 2 && first[2]&0x80 == 0
+	if !isRunLengthEncoded {
+		scanline[0] = first
+		for x := 1; x < width; x++ {
+			if _, err := io.ReadFull(r, scanline[x][:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if int(first[2])<<8|int(first[3]) != width {
+		return fmt.Errorf("scanline width mismatch")
+	}
+
+	// Each of the four components is stored separately as a sequence of runs and literals.
+	for component := 0; component < 4; component++ {
+		for x := 0; x < width; {
+			count, err := r.ReadByte()
+			if err != nil {
+				return err
+			}
+			if count > 128 {
+				n := int(count - 128)
+				if x+n > width {
+					return fmt.Errorf("run overflows scanline")
+				}
+				value, err := r.ReadByte()
+				if err != nil {
+					return err
+				}
+				for i := 0; i < n; i++ {
+					scanline[x][component] = value
+					x++
+				}
+			} else {
+				n := int(count)
+				if n == 0 || x+n > width {
+					return fmt.Errorf("invalid literal length")
+				}
+				for i := 0; i < n; i++ {
+					value, err := r.ReadByte()
+					if err != nil {
+						return err
+					}
+					scanline[x][component] = value
+					x++
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+func fromRGBE(rgbe [4]byte) floatcolor.Float64Color {
+	if rgbe[3] == 0 {
+		return floatcolor.Black
+	}
+	f := math.Ldexp(1, int(rgbe[3])-(128+8))
+	return floatcolor.New(
+		(float64(rgbe[0])+0.5)*f,
+		(float64(rgbe[1])+0.5)*f,
+		(float64(rgbe[2])+0.5)*f,
+	)
+}
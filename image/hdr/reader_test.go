@@ -0,0 +1,77 @@
+package hdr
+
+import (
+	"bytes"
+	"image"
+	"strings"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeUncompressed(t *testing.T) {
+	data := "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y 1 +X 2\n" +
+		string([]byte{128, 64, 0, 129, 128, 128, 128, 136})
+
+	img, err := Decode(strings.NewReader(data))
+
+	require.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 2, 1), img.Bounds())
+	test.AssertAlmost(t, floatcolor.New(128.5/128, 64.5/128, 0.5/128), img.At(0, 0))
+	test.AssertAlmost(t, floatcolor.New(128.5, 128.5, 128.5), img.At(1, 0))
+}
+
+func TestDecodeRunLengthEncoded(t *testing.T) {
+	var data bytes.Buffer
+	data.WriteString("#?RGBE\n\n-Y 1 +X 8\n")
+	data.Write([]byte{2, 2, 0, 8})
+	data.Write([]byte{128 + 8, 128})                 // red: run of 8
+	data.Write([]byte{8, 0, 16, 32, 64, 0, 0, 0, 0}) // green: 8 literals
+	data.Write([]byte{4, 1, 2, 3, 4, 128 + 4, 0})    // blue: 4 literals then a run of 4
+	data.Write([]byte{128 + 8, 129})                 // exponent: run of 8
+
+	img, err := Decode(&data)
+
+	require.NoError(t, err)
+	test.AssertAlmost(t, floatcolor.New(128.5/128, 0.5/128, 1.5/128), img.At(0, 0))
+	test.AssertAlmost(t, floatcolor.New(128.5/128, 64.5/128, 4.5/128), img.At(3, 0))
+	test.AssertAlmost(t, floatcolor.New(128.5/128, 0.5/128, 0.5/128), img.At(7, 0))
+}
+
+func TestDecodeRejectsOtherFormats(t *testing.T) {
+	_, err := Decode(strings.NewReader("P3\n1 1\n255\n0 0 0\n"))
+
+	assert.ErrorContains(t, err, "not a radiance image")
+}
+
+func TestDecodeRejectsXYZE(t *testing.T) {
+	_, err := Decode(strings.NewReader("#?RADIANCE\nFORMAT=32-bit_rle_xyze\n\n-Y 1 +X 1\n\x80\x80\x80\x80"))
+
+	assert.ErrorContains(t, err, "unsupported format")
+}
+
+func TestDecodeTruncated(t *testing.T) {
+	_, err := Decode(strings.NewReader("#?RADIANCE\n\n-Y 2 +X 1\n\x80\x80\x80\x80"))
+
+	assert.Error(t, err)
+}
+
+func TestDecodeRejectsHugeResolution(t *testing.T) {
+	_, err := Decode(strings.NewReader("#?RADIANCE\n\n-Y 100000 +X 100000\n\x80\x80\x80\x80"))
+
+	assert.ErrorContains(t, err, "too large")
+}
+
+func TestImageDecodeRecognizesFormat(t *testing.T) {
+	data := "#?RADIANCE\n\n-Y 3 +X 4\n"
+
+	config, format, err := image.DecodeConfig(strings.NewReader(data))
+
+	require.NoError(t, err)
+	assert.Equal(t, "hdr", format)
+	assert.Equal(t, 4, config.Width)
+	assert.Equal(t, 3, config.Height)
+}
@@ -0,0 +1,43 @@
+package hdr
+
+import (
+	"bufio"
+	"fmt"
+	"image"
+	"io"
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+)
+
+// Encode writes m as an uncompressed Radiance RGBE image. Colors brighter than 1 are preserved
+// when m contains floatcolor.Float64Color pixels, such as a canvas.Canvas.
+func Encode(w io.Writer, m image.Image) error {
+	bw := bufio.NewWriter(w)
+	bounds := m.Bounds()
+	fmt.Fprintf(bw, "#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y %v +X %v\n", bounds.Dy(), bounds.Dx())
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := floatcolor.Float64Model.Convert(m.At(x, y)).(floatcolor.Float64Color)
+			rgbe := toRGBE(c)
+			if _, err := bw.Write(rgbe[:]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+func toRGBE(c floatcolor.Float64Color) [4]byte {
+	r, g, b := c.RGB()
+	r, g, b = math.Max(r, 0), math.Max(g, 0), math.Max(b, 0)
+	brightest := math.Max(r, math.Max(g, b))
+	if brightest < 1e-32 {
+		return [4]byte{}
+	}
+	mantissa, exponent := math.Frexp(brightest)
+	scale := mantissa * 256 / brightest
+	return [4]byte{byte(r * scale), byte(g * scale), byte(b * scale), byte(exponent + 128)}
+}
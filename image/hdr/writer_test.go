@@ -0,0 +1,50 @@
+package hdr
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/canvas"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncodeHeader(t *testing.T) {
+	c := canvas.New(5, 3)
+	var b bytes.Buffer
+
+	err := Encode(&b, c)
+
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(b.Bytes(), []byte("#?RADIANCE\nFORMAT=32-bit_rle_rgbe\n\n-Y 3 +X 5\n")))
+}
+
+func TestEncodeDecodeRoundTripPreservesBrightColors(t *testing.T) {
+	c := canvas.New(2, 2)
+	c.WritePixel(0, 0, floatcolor.New(1, 0.5, 0.25))
+	c.WritePixel(1, 0, floatcolor.New(20, 10, 0))
+	c.WritePixel(0, 1, floatcolor.New(0.001, 0.002, 0.003))
+	var b bytes.Buffer
+
+	require.NoError(t, Encode(&b, c))
+	img, err := Decode(&b)
+	require.NoError(t, err)
+
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 2; x++ {
+			expected := c.PixelAt(uint(x), uint(y))
+			actual := img.At(x, y).(floatcolor.Float64Color)
+			r, g, bl := expected.RGB()
+			brightest := r
+			if g > brightest {
+				brightest = g
+			}
+			if bl > brightest {
+				brightest = bl
+			}
+			// RGBE has 8 bits of precision relative to the brightest channel
+			assert.True(t, expected.AlmostEqual(actual, brightest/100+1e-9), "expected %v, got %v", expected, actual)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Package texture samples images as textures, for image patterns and environment maps.
+package texture
+
+import (
+	"image"
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+)
+
+// Wrap is how texture coordinates outside the range 0 to 1 are treated.
+type Wrap int
+
+const (
+	// Clamp extends the pixels at the edge of the image.
+	Clamp Wrap = iota
+	// Repeat tiles the image.
+	Repeat
+)
+
+// Bilinear returns the color of img at texture coordinates u and v, which run from 0 to 1 left
+// to right and top to bottom, blending the four nearest pixels. wrapU and wrapV are how
+// coordinates beyond the edges are treated horizontally and vertically.
+func Bilinear(img image.Image, u, v float64, wrapU, wrapV Wrap) floatcolor.Float64Color {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	x := u*float64(width) - 0.5
+	y := v*float64(height) - 0.5
+	x0, y0 := math.Floor(x), math.Floor(y)
+	fx, fy := x-x0, y-y0
+
+	pixel := func(px, py int) floatcolor.Float64Color {
+		px = wrap(px, width, wrapU)
+		py = wrap(py, height, wrapV)
+		c := img.At(bounds.Min.X+px, bounds.Min.Y+py)
+		return floatcolor.Float64Model.Convert(c).(floatcolor.Float64Color)
+	}
+
+	ix, iy := int(x0), int(y0)
+	top := pixel(ix, iy).Mul(1 - fx).Add(pixel(ix+1, iy).Mul(fx))
+	bottom := pixel(ix, iy+1).Mul(1 - fx).Add(pixel(ix+1, iy+1).Mul(fx))
+	return top.Mul(1 - fy).Add(bottom.Mul(fy))
+}
+
+// wrap brings the pixel index i into the range 0 to size-1.
+func wrap(i, size int, w Wrap) int {
+	if w == Repeat {
+		return ((i % size) + size) % size
+	}
+	if i < 0 {
+		return 0
+	}
+	if i > size-1 {
+		return size - 1
+	}
+	return i
+}
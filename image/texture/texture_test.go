@@ -0,0 +1,44 @@
+package texture
+
+import (
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/canvas"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/test"
+)
+
+func testImage() canvas.Canvas {
+	img := canvas.New(2, 2)
+	img.WritePixel(0, 0, floatcolor.Red)
+	img.WritePixel(1, 0, floatcolor.Green)
+	img.WritePixel(0, 1, floatcolor.Blue)
+	img.WritePixel(1, 1, floatcolor.White)
+	return img
+}
+
+func TestBilinearAtPixelCenters(t *testing.T) {
+	img := testImage()
+
+	test.AssertAlmost(t, floatcolor.Red, Bilinear(img, 0.25, 0.25, Clamp, Clamp))
+	test.AssertAlmost(t, floatcolor.Green, Bilinear(img, 0.75, 0.25, Clamp, Clamp))
+	test.AssertAlmost(t, floatcolor.Blue, Bilinear(img, 0.25, 0.75, Clamp, Clamp))
+	test.AssertAlmost(t, floatcolor.White, Bilinear(img, 0.75, 0.75, Clamp, Clamp))
+}
+
+func TestBilinearBlendsBetweenPixels(t *testing.T) {
+	img := testImage()
+
+	test.AssertAlmost(t, floatcolor.New(0.5, 0.5, 0), Bilinear(img, 0.5, 0.25, Clamp, Clamp))
+	test.AssertAlmost(t, floatcolor.New(0.5, 0, 0.5), Bilinear(img, 0.25, 0.5, Clamp, Clamp))
+}
+
+func TestBilinearWrapModes(t *testing.T) {
+	img := testImage()
+
+	// At the left edge, repeating blends with the pixel on the right edge.
+	test.AssertAlmost(t, floatcolor.Red, Bilinear(img, 0, 0.25, Clamp, Clamp))
+	test.AssertAlmost(t, floatcolor.New(0.5, 0.5, 0), Bilinear(img, 0, 0.25, Repeat, Clamp))
+	test.AssertAlmost(t, floatcolor.Red, Bilinear(img, 1.25, -0.75, Repeat, Repeat))
+	test.AssertAlmost(t, floatcolor.Blue, Bilinear(img, -1, 2, Clamp, Clamp))
+}
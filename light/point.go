@@ -1,6 +1,8 @@
 package light
 
 import (
+	"math"
+
 	"github.com/danieltmartin/ray-tracer/floatcolor"
 	"github.com/danieltmartin/ray-tracer/tuple"
 )
@@ -8,19 +10,77 @@ import (
 type PointLight struct {
 	position  tuple.Tuple
 	intensity floatcolor.Float64Color
+
+	// direction is the direction light travels in from a directional light, or the zero
+	// vector for a light at position.
+	direction tuple.Tuple
 }
 
 func NewPointLight(position tuple.Tuple, intensity floatcolor.Float64Color) PointLight {
 	if !position.IsPoint() {
 		panic("light position set to non-position")
 	}
-	return PointLight{position, intensity}
+	return PointLight{position: position, intensity: intensity}
+}
+
+// NewDirectionalLight creates a light so far away, like the sun, that its light travels in the
+// same direction everywhere in the scene and is never blocked by anything beyond the scene.
+func NewDirectionalLight(direction tuple.Tuple, intensity floatcolor.Float64Color) PointLight {
+	if !direction.IsVector() {
+		panic("light direction set to non-vector")
+	}
+	if direction.Mag() == 0 {
+		panic("light direction can't be a zero vector")
+	}
+	return PointLight{direction: direction.Norm(), intensity: intensity}
 }
 
+// Position returns the position of the light. Directional lights have no position, so use
+// DirectionFrom and DistanceFrom for lights that may be directional.
 func (p *PointLight) Position() tuple.Tuple {
+	if p.IsDirectional() {
+		panic("directional light has no position")
+	}
 	return p.position
 }
 
+// SetPosition moves a light that isn't directional.
+func (p *PointLight) SetPosition(position tuple.Tuple) {
+	if p.IsDirectional() {
+		panic("directional light has no position")
+	}
+	if !position.IsPoint() {
+		panic("light position set to non-position")
+	}
+	p.position = position
+}
+
 func (p *PointLight) Intensity() floatcolor.Float64Color {
 	return p.intensity
 }
+
+func (p *PointLight) SetIntensity(intensity floatcolor.Float64Color) {
+	p.intensity = intensity
+}
+
+// IsDirectional reports whether the light was created with NewDirectionalLight.
+func (p *PointLight) IsDirectional() bool {
+	return p.direction != tuple.Tuple{}
+}
+
+// DirectionFrom returns the unit vector pointing from point towards the light.
+func (p *PointLight) DirectionFrom(point tuple.Tuple) tuple.Tuple {
+	if p.IsDirectional() {
+		return p.direction.Neg()
+	}
+	return p.position.Sub(point).Norm()
+}
+
+// DistanceFrom returns the distance from point to the light, which is infinite for directional
+// lights.
+func (p *PointLight) DistanceFrom(point tuple.Tuple) float64 {
+	if p.IsDirectional() {
+		return math.Inf(1)
+	}
+	return p.position.Sub(point).Mag()
+}
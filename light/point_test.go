@@ -1,6 +1,7 @@
 package light
 
 import (
+	"math"
 	"testing"
 
 	"github.com/danieltmartin/ray-tracer/floatcolor"
@@ -17,3 +18,38 @@ func TestPointLightHasPositionAndIntensity(t *testing.T) {
 	assert.Equal(t, intensity, light.intensity)
 	assert.Equal(t, position, light.position)
 }
+
+func TestPointLightDirectionAndDistance(t *testing.T) {
+	light := NewPointLight(tuple.NewPoint(0, 10, 0), floatcolor.White)
+
+	assert.False(t, light.IsDirectional())
+	assert.Equal(t, tuple.NewVector(0, 1, 0), light.DirectionFrom(tuple.NewPoint(0, 2, 0)))
+	assert.Equal(t, 8.0, light.DistanceFrom(tuple.NewPoint(0, 2, 0)))
+}
+
+func TestDirectionalLightIsInfinitelyFarAway(t *testing.T) {
+	light := NewDirectionalLight(tuple.NewVector(0, -2, 0), floatcolor.White)
+
+	assert.True(t, light.IsDirectional())
+	assert.Equal(t, tuple.NewVector(0, 1, 0), light.DirectionFrom(tuple.NewPoint(3, 2, 1)))
+	assert.Equal(t, tuple.NewVector(0, 1, 0), light.DirectionFrom(tuple.NewPoint(-5, 0, 7)))
+	assert.True(t, math.IsInf(light.DistanceFrom(tuple.NewPoint(3, 2, 1)), 1))
+	assert.Panics(t, func() { light.Position() })
+}
+
+func TestDirectionalLightNeedsADirection(t *testing.T) {
+	assert.Panics(t, func() { NewDirectionalLight(tuple.NewVector(0, 0, 0), floatcolor.White) })
+}
+
+func TestMovingAndDimmingLight(t *testing.T) {
+	light := NewPointLight(tuple.NewPoint(0, 10, 0), floatcolor.White)
+
+	light.SetPosition(tuple.NewPoint(1, 2, 3))
+	light.SetIntensity(floatcolor.New(0.5, 0.5, 0.5))
+
+	assert.Equal(t, tuple.NewPoint(1, 2, 3), light.Position())
+	assert.Equal(t, floatcolor.New(0.5, 0.5, 0.5), light.Intensity())
+	assert.Panics(t, func() { light.SetPosition(tuple.NewVector(1, 2, 3)) })
+	sun := NewDirectionalLight(tuple.NewVector(0, -1, 0), floatcolor.White)
+	assert.Panics(t, func() { sun.SetPosition(tuple.NewPoint(1, 2, 3)) })
+}
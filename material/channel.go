@@ -0,0 +1,104 @@
+package material
+
+import (
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Channel identifies a scalar material property that can vary across a surface.
+type Channel int
+
+const (
+	AmbientChannel Channel = iota
+	DiffuseChannel
+	SpecularChannel
+	ShininessChannel
+	ReflectiveChannel
+	TransparencyChannel
+	RoughnessChannel
+	MetallicChannel
+	// RefractiveIndexChannel scales how much the refractive index differs from 1, so that black
+	// parts of the pattern bend light no more than empty space.
+	RefractiveIndexChannel
+	// AbsorptionChannel scales the absorption density.
+	AbsorptionChannel
+	// VolumeDensityChannel scales the volume density, making smoke and fog patchy.
+	VolumeDensityChannel
+	// DispersionChannel scales how much the refractive index varies with wavelength.
+	DispersionChannel
+
+	channelCount = iota
+)
+
+// WithChannel makes a property vary by multiplying it by the brightness of pattern, from 0
+// where the pattern is black to 1 where it's white. For example, a reflectivity mask can make
+// parts of a surface reflective and others matte. Nil removes the channel's pattern, leaving
+// the property constant.
+//
+// Surface properties are evaluated where a ray hits the surface. Refractive index and
+// dispersion are evaluated where a ray crosses into or out of the object, absorption at the
+// middle of each path through it, and volume density at each point sampled along the path.
+func (m Material) WithChannel(channel Channel, pattern Pattern) Material {
+	c := m.copy()
+	c.channels[channel] = pattern
+	return c
+}
+
+// Channel returns the pattern varying a property, or nil if it is constant.
+func (m Material) Channel(channel Channel) Pattern {
+	return m.channels[channel]
+}
+
+// At returns the material with the properties it has at worldPoint on object, evaluating the
+// patterns of any channels. The returned material has no channels.
+func (m Material) At(object Object, worldPoint tuple.Tuple) Material {
+	if m.channels == [channelCount]Pattern{} {
+		return m
+	}
+	c := m.copy()
+	for channel, pattern := range m.channels {
+		if pattern == nil {
+			continue
+		}
+		r, g, b := pattern.colorAtObject(object, worldPoint).RGB()
+		c.scale(Channel(channel), (r+g+b)/3)
+	}
+	c.channels = [channelCount]Pattern{}
+	return c
+}
+
+// scale multiplies the property of channel by f.
+func (m *Material) scale(channel Channel, f float64) {
+	if channel == RefractiveIndexChannel {
+		m.refractiveIndex = 1 + (m.refractiveIndex-1)*f
+		return
+	}
+	*m.property(channel) *= f
+}
+
+func (m *Material) property(channel Channel) *float64 {
+	switch channel {
+	case AmbientChannel:
+		return &m.ambient
+	case DiffuseChannel:
+		return &m.diffuse
+	case SpecularChannel:
+		return &m.specular
+	case ShininessChannel:
+		return &m.shininess
+	case ReflectiveChannel:
+		return &m.reflective
+	case TransparencyChannel:
+		return &m.transparency
+	case RoughnessChannel:
+		return &m.roughness
+	case MetallicChannel:
+		return &m.metallic
+	case AbsorptionChannel:
+		return &m.absorptionDensity
+	case VolumeDensityChannel:
+		return &m.volumeDensity
+	case DispersionChannel:
+		return &m.cauchyB
+	}
+	panic("unknown material channel")
+}
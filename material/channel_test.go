@@ -0,0 +1,92 @@
+package material
+
+import (
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/light"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithChannelDoesNotModifyOriginal(t *testing.T) {
+	mask := NewStripePattern(floatcolor.White, floatcolor.Black)
+	m := Default.WithChannel(ReflectiveChannel, mask)
+
+	assert.Equal(t, mask, m.Channel(ReflectiveChannel))
+	assert.Nil(t, Default.Channel(ReflectiveChannel))
+}
+
+func TestAtWithoutChannelsReturnsMaterial(t *testing.T) {
+	m := Default.WithReflective(0.5)
+
+	assert.Equal(t, m, m.At(obj, tuple.NewPoint(1, 2, 3)))
+}
+
+func TestAtScalesPropertiesByChannelBrightness(t *testing.T) {
+	mask := NewStripePattern(floatcolor.White, floatcolor.New(0.2, 0.4, 0))
+	m := Default.
+		WithReflective(0.5).
+		WithShininess(100).
+		WithChannel(ReflectiveChannel, mask).
+		WithChannel(ShininessChannel, SolidPattern(floatcolor.New(0.5, 0.5, 0.5)))
+
+	white := m.At(obj, tuple.NewPoint(0.5, 0, 0))
+	dark := m.At(obj, tuple.NewPoint(1.5, 0, 0))
+
+	assert.Equal(t, 0.5, white.Reflective())
+	assert.InDelta(t, 0.1, dark.Reflective(), 1e-9)
+	assert.Equal(t, 50.0, white.Shininess())
+	assert.Equal(t, 50.0, dark.Shininess())
+	assert.Nil(t, dark.Channel(ReflectiveChannel))
+	assert.Equal(t, 0.5, m.Reflective())
+}
+
+func TestEveryChannelScalesItsProperty(t *testing.T) {
+	half := SolidPattern(floatcolor.New(0.5, 0.5, 0.5))
+	m := New(SolidPattern(floatcolor.White), 1, 1, 1, 1, 1, 1, 1).
+		WithRoughness(1).
+		WithMetallic(1).
+		WithCauchy(1.5, 0.01).
+		WithRefractiveIndex(1.5).
+		WithAbsorption(floatcolor.Black, 1).
+		WithVolumeDensity(1)
+	for channel := AmbientChannel; channel < channelCount; channel++ {
+		m = m.WithChannel(channel, half)
+	}
+
+	at := m.At(obj, tuple.NewPoint(0, 0, 0))
+
+	assert.Equal(t, 0.5, at.Ambient())
+	assert.Equal(t, 0.5, at.Diffuse())
+	assert.Equal(t, 0.5, at.Specular())
+	assert.Equal(t, 0.5, at.Shininess())
+	assert.Equal(t, 0.5, at.Reflective())
+	assert.Equal(t, 0.5, at.Transparency())
+	assert.Equal(t, 0.5, at.Roughness())
+	assert.Equal(t, 0.5, at.Metallic())
+	assert.Equal(t, 1.25, at.RefractiveIndex())
+	assert.Equal(t, 0.5, at.AbsorptionDensity())
+	assert.Equal(t, 0.5, at.VolumeDensity())
+	_, b := at.CauchyCoefficients()
+	assert.Equal(t, 0.005, b)
+}
+
+func TestLightingUsesSpecularMap(t *testing.T) {
+	specularMap := NewStripePattern(floatcolor.White, floatcolor.Black)
+	m := Default.WithChannel(SpecularChannel, specularMap)
+	eyev := tuple.NewVector(0, 0, -1)
+	normalv := tuple.NewVector(0, 0, -1)
+	l := light.NewPointLight(tuple.NewPoint(0, 0, -10), floatcolor.White)
+
+	shinyPoint := tuple.NewPoint(0.5, 0, 0)
+	mattePoint := tuple.NewPoint(-0.5, 0, 0)
+
+	shiny := m.Lighting(obj, l, shinyPoint, eyev, normalv, false)
+	matte := m.Lighting(obj, l, mattePoint, eyev, normalv, false)
+
+	test.AssertAlmost(t, Default.Lighting(obj, l, shinyPoint, eyev, normalv, false), shiny)
+	test.AssertAlmost(t, Default.WithSpecular(0).Lighting(obj, l, mattePoint, eyev, normalv, false), matte)
+	assert.NotEqual(t, shiny, matte)
+}
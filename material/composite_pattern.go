@@ -0,0 +1,110 @@
+package material
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// BlendPattern mixes two patterns by a fixed weight. Like nested patterns, both are evaluated in
+// the blend pattern's space.
+type BlendPattern struct {
+	pattern1, pattern2 Pattern
+	weight             float64
+	patternTransform
+}
+
+// NewBlendPattern creates a BlendPattern taking weight of its color from pattern2 and the rest
+// from pattern1, so 0 gives pattern1 alone and 0.5 an even mix.
+func NewBlendPattern(pattern1, pattern2 Pattern, weight float64) BlendPattern {
+	if weight < 0 || weight > 1 {
+		panic("blend weight must be between 0 and 1")
+	}
+	return BlendPattern{pattern1, pattern2, weight, patternTransform(matrix.Identity4())}
+}
+
+func (b BlendPattern) WithTransform(transform matrix.Matrix) BlendPattern {
+	return BlendPattern{b.pattern1, b.pattern2, b.weight, patternTransform(transform.Inverse())}
+}
+
+func (b BlendPattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	point := toPatternPoint(b, object, worldPoint)
+	c1 := nestedColorAt(b.pattern1, object, worldPoint, point)
+	c2 := nestedColorAt(b.pattern2, object, worldPoint, point)
+	return c1.Mul(1 - b.weight).Add(c2.Mul(b.weight))
+}
+
+// MaskPattern chooses between two patterns using the brightness of a third, giving the first
+// pattern where the mask is black, the second where it's white, and a mix in between.
+type MaskPattern struct {
+	mask, pattern1, pattern2 Pattern
+	patternTransform
+}
+
+func NewMaskPattern(mask, pattern1, pattern2 Pattern) MaskPattern {
+	return MaskPattern{mask, pattern1, pattern2, patternTransform(matrix.Identity4())}
+}
+
+func (m MaskPattern) WithTransform(transform matrix.Matrix) MaskPattern {
+	return MaskPattern{m.mask, m.pattern1, m.pattern2, patternTransform(transform.Inverse())}
+}
+
+func (m MaskPattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	point := toPatternPoint(m, object, worldPoint)
+	r, g, b := nestedColorAt(m.mask, object, worldPoint, point).RGB()
+	weight := math.Max(0, math.Min(1, (r+g+b)/3))
+	// Skip evaluating a pattern that doesn't contribute, which matters for expensive patterns.
+	switch weight {
+	case 0:
+		return nestedColorAt(m.pattern1, object, worldPoint, point)
+	case 1:
+		return nestedColorAt(m.pattern2, object, worldPoint, point)
+	}
+	c1 := nestedColorAt(m.pattern1, object, worldPoint, point)
+	c2 := nestedColorAt(m.pattern2, object, worldPoint, point)
+	return c1.Mul(1 - weight).Add(c2.Mul(weight))
+}
+
+// Operator is an arithmetic operation combining the colors of two patterns.
+type Operator int
+
+const (
+	Add Operator = iota
+	Subtract
+	Multiply
+)
+
+// ArithmeticPattern combines the colors of two patterns channel by channel, for example
+// multiplying a pattern by a darkening mask or adding highlights. Results aren't clamped.
+type ArithmeticPattern struct {
+	operator           Operator
+	pattern1, pattern2 Pattern
+	patternTransform
+}
+
+// NewArithmeticPattern creates a pattern applying operator to the colors of pattern1 and
+// pattern2, in that order.
+func NewArithmeticPattern(operator Operator, pattern1, pattern2 Pattern) ArithmeticPattern {
+	return ArithmeticPattern{operator, pattern1, pattern2, patternTransform(matrix.Identity4())}
+}
+
+func (a ArithmeticPattern) WithTransform(transform matrix.Matrix) ArithmeticPattern {
+	return ArithmeticPattern{a.operator, a.pattern1, a.pattern2, patternTransform(transform.Inverse())}
+}
+
+func (a ArithmeticPattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	point := toPatternPoint(a, object, worldPoint)
+	c1 := nestedColorAt(a.pattern1, object, worldPoint, point)
+	c2 := nestedColorAt(a.pattern2, object, worldPoint, point)
+	switch a.operator {
+	case Add:
+		return c1.Add(c2)
+	case Subtract:
+		return c1.Sub(c2)
+	case Multiply:
+		return c1.Hadamard(c2)
+	}
+	panic("unknown pattern operator")
+}
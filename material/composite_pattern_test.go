@@ -0,0 +1,60 @@
+package material
+
+import (
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlendPatternMixesByWeight(t *testing.T) {
+	o := dummyObject(matrix.Identity4())
+	p := NewBlendPattern(SolidPattern(floatcolor.Red), SolidPattern(floatcolor.Blue), 0.25)
+
+	test.AssertAlmost(t, floatcolor.New(0.75, 0, 0.25), p.colorAtObject(&o, tuple.NewPoint(0, 0, 0)))
+}
+
+func TestBlendPatternPanicsWithInvalidWeight(t *testing.T) {
+	assert.Panics(t, func() { NewBlendPattern(SolidPattern(floatcolor.Red), SolidPattern(floatcolor.Blue), 1.5) })
+}
+
+func TestBlendPatternEvaluatesSubPatternsInItsSpace(t *testing.T) {
+	o := dummyObject(matrix.Identity4())
+	stripes := NewStripePattern(floatcolor.White, floatcolor.Black)
+	p := NewBlendPattern(stripes, stripes, 0.5).WithTransform(transform.Scaling(2, 2, 2))
+
+	assert.Equal(t, floatcolor.White, p.colorAtObject(&o, tuple.NewPoint(1.5, 0, 0)))
+	assert.Equal(t, floatcolor.Black, p.colorAtObject(&o, tuple.NewPoint(2.5, 0, 0)))
+}
+
+func TestMaskPatternChoosesByBrightness(t *testing.T) {
+	o := dummyObject(matrix.Identity4())
+	mask := NewStripePattern(floatcolor.Black, floatcolor.White)
+	p := NewMaskPattern(mask, SolidPattern(floatcolor.Red), SolidPattern(floatcolor.Green))
+
+	assert.Equal(t, floatcolor.Red, p.colorAtObject(&o, tuple.NewPoint(0.5, 0, 0)))
+	assert.Equal(t, floatcolor.Green, p.colorAtObject(&o, tuple.NewPoint(1.5, 0, 0)))
+}
+
+func TestMaskPatternMixesForGreyMask(t *testing.T) {
+	o := dummyObject(matrix.Identity4())
+	mask := SolidPattern(floatcolor.New(0.5, 0.5, 0.5))
+	p := NewMaskPattern(mask, SolidPattern(floatcolor.Red), SolidPattern(floatcolor.Green))
+
+	test.AssertAlmost(t, floatcolor.New(0.5, 0.5, 0), p.colorAtObject(&o, tuple.NewPoint(0, 0, 0)))
+}
+
+func TestArithmeticPattern(t *testing.T) {
+	o := dummyObject(matrix.Identity4())
+	a := SolidPattern(floatcolor.New(0.5, 0.4, 0.2))
+	b := SolidPattern(floatcolor.New(0.2, 0.5, 1))
+	point := tuple.NewPoint(0, 0, 0)
+
+	test.AssertAlmost(t, floatcolor.New(0.7, 0.9, 1.2), NewArithmeticPattern(Add, a, b).colorAtObject(&o, point))
+	test.AssertAlmost(t, floatcolor.New(0.3, -0.1, -0.8), NewArithmeticPattern(Subtract, a, b).colorAtObject(&o, point))
+	test.AssertAlmost(t, floatcolor.New(0.1, 0.2, 0.2), NewArithmeticPattern(Multiply, a, b).colorAtObject(&o, point))
+}
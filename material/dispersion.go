@@ -0,0 +1,58 @@
+package material
+
+// Wavelengths in nanometres of the Fraunhofer spectral lines used to characterize the dispersion
+// of optical materials.
+const (
+	WavelengthC = 656.3 // Red hydrogen line
+	WavelengthD = 587.6 // Yellow helium line at which RefractiveIndex is defined
+	WavelengthF = 486.1 // Blue hydrogen line
+)
+
+// RefractiveIndexAt returns the refractive index for light of the given wavelength in nanometres
+// using Cauchy's equation. Without dispersion this is always RefractiveIndex.
+func (m Material) RefractiveIndexAt(wavelength float64) float64 {
+	if m.cauchyB == 0 {
+		return m.refractiveIndex
+	}
+	return m.refractiveIndex + m.cauchyB*(inverseSquareMicrometres(wavelength)-inverseSquareMicrometres(WavelengthD))
+}
+
+// Dispersive reports whether the refractive index of the material varies with wavelength.
+func (m Material) Dispersive() bool {
+	return m.cauchyB != 0
+}
+
+// CauchyCoefficients returns the coefficients A and B of Cauchy's equation n = A + B/λ², with λ
+// in micrometres.
+func (m Material) CauchyCoefficients() (a, b float64) {
+	return m.refractiveIndex - m.cauchyB*inverseSquareMicrometres(WavelengthD), m.cauchyB
+}
+
+// WithCauchy sets the refractive index as a function of wavelength using the coefficients of
+// Cauchy's equation n = A + B/λ², with λ in micrometres. RefractiveIndex becomes the index at
+// WavelengthD.
+func (m Material) WithCauchy(a, b float64) Material {
+	c := m.copy()
+	c.refractiveIndex = a + b*inverseSquareMicrometres(WavelengthD)
+	c.cauchyB = b
+	return c
+}
+
+// WithAbbeNumber sets the dispersion of the material from its Abbe number, keeping the current
+// refractive index at WavelengthD. Lower Abbe numbers disperse more; diamond is about 55 and
+// crown glass about 59. An Abbe number of 0 disables dispersion.
+func (m Material) WithAbbeNumber(abbe float64) Material {
+	c := m.copy()
+	if abbe == 0 {
+		c.cauchyB = 0
+		return c
+	}
+	spread := inverseSquareMicrometres(WavelengthF) - inverseSquareMicrometres(WavelengthC)
+	c.cauchyB = (m.refractiveIndex - 1) / (abbe * spread)
+	return c
+}
+
+func inverseSquareMicrometres(wavelength float64) float64 {
+	micrometres := wavelength / 1000
+	return 1 / (micrometres * micrometres)
+}
@@ -0,0 +1,47 @@
+package material
+
+import (
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNonDispersiveRefractiveIndexIsConstant(t *testing.T) {
+	m := Default.WithRefractiveIndex(1.5)
+
+	assert.False(t, m.Dispersive())
+	assert.Equal(t, 1.5, m.RefractiveIndexAt(WavelengthC))
+	assert.Equal(t, 1.5, m.RefractiveIndexAt(WavelengthF))
+}
+
+func TestAbbeNumberDefinesDispersion(t *testing.T) {
+	m := Default.WithRefractiveIndex(2.417).WithAbbeNumber(55.3)
+
+	nC := m.RefractiveIndexAt(WavelengthC)
+	nD := m.RefractiveIndexAt(WavelengthD)
+	nF := m.RefractiveIndexAt(WavelengthF)
+
+	assert.True(t, m.Dispersive())
+	test.AssertAlmost(t, 2.417, nD)
+	assert.Greater(t, nF, nD)
+	assert.Greater(t, nD, nC)
+	test.AssertAlmost(t, 55.3, (nD-1)/(nF-nC))
+}
+
+func TestCauchyCoefficients(t *testing.T) {
+	m := Default.WithCauchy(1.5046, 0.0042)
+
+	a, b := m.CauchyCoefficients()
+
+	test.AssertAlmost(t, 1.5046, a)
+	test.AssertAlmost(t, 0.0042, b)
+	test.AssertAlmost(t, 1.5046+0.0042/0.25, m.RefractiveIndexAt(500))
+	test.AssertAlmost(t, 1.5046+0.0042/(0.5876*0.5876), m.RefractiveIndex())
+}
+
+func TestAbbeNumberZeroDisablesDispersion(t *testing.T) {
+	m := Default.WithRefractiveIndex(1.5).WithAbbeNumber(40).WithAbbeNumber(0)
+
+	assert.False(t, m.Dispersive())
+}
@@ -0,0 +1,83 @@
+package material
+
+import (
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// UVMapper is implemented by objects that can map points on their surface to two-dimensional
+// texture coordinates.
+type UVMapper interface {
+	UVAt(worldPoint tuple.Tuple) (u, v float64)
+}
+
+// PatternPoint describes the point at which a FuncPattern is being evaluated.
+type PatternPoint struct {
+	// World is the point in world space.
+	World tuple.Tuple
+	// Object is the point in the object's space, or in the enclosing pattern's space if the
+	// pattern is nested in another.
+	Object tuple.Tuple
+	// Local is the point in the pattern's space, after applying the pattern's transform to
+	// Object.
+	Local tuple.Tuple
+	// U and V are the object's texture coordinates at the point if HasUV is set.
+	U, V  float64
+	HasUV bool
+
+	object Object
+}
+
+// ColorOf evaluates another pattern at the point, nested within the pattern being evaluated
+// in the same way as the patterns given to NewNestedStripePattern.
+func (p PatternPoint) ColorOf(pattern Pattern) floatcolor.Float64Color {
+	return nestedColorAt(pattern, p.object, p.World, p.Local)
+}
+
+// PatternFunc computes the color of a pattern at a point.
+type PatternFunc func(p PatternPoint) floatcolor.Float64Color
+
+// FuncPattern is a pattern defined by a function, allowing patterns to be written outside this
+// package.
+type FuncPattern struct {
+	fn PatternFunc
+	patternTransform
+}
+
+func NewFuncPattern(fn PatternFunc) FuncPattern {
+	return FuncPattern{fn, patternTransform(matrix.Identity4())}
+}
+
+func (f FuncPattern) WithTransform(transform matrix.Matrix) FuncPattern {
+	return FuncPattern{f.fn, patternTransform(transform.Inverse())}
+}
+
+func (f FuncPattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	objectPoint := object.WorldPointToLocal(worldPoint)
+	p := PatternPoint{
+		World:  worldPoint,
+		Object: objectPoint,
+		Local:  f.inverseTransform().MulTuple(objectPoint),
+		object: object,
+	}
+	p.U, p.V, p.HasUV = uvAt(object, worldPoint)
+	return f.fn(p)
+}
+
+// uvAt returns the texture coordinates of object at worldPoint, if it has them.
+func uvAt(object Object, worldPoint tuple.Tuple) (u, v float64, ok bool) {
+	for {
+		nested, isNested := object.(patternSpace)
+		if !isNested {
+			break
+		}
+		object = nested.Object
+	}
+	mapper, ok := object.(UVMapper)
+	if !ok {
+		return 0, 0, false
+	}
+	u, v = mapper.UVAt(worldPoint)
+	return u, v, true
+}
@@ -0,0 +1,73 @@
+package material
+
+import (
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+type uvObject struct {
+	dummyObject
+}
+
+func (uvObject) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return worldPoint.X / 10, worldPoint.Y / 10
+}
+
+func TestFuncPatternReceivesPoints(t *testing.T) {
+	o := dummyObject(transform.Scaling(2, 2, 2).Inverse())
+	var got PatternPoint
+	p := NewFuncPattern(func(p PatternPoint) floatcolor.Float64Color {
+		got = p
+		return floatcolor.Red
+	}).WithTransform(transform.Translation(1, 0, 0))
+
+	c := p.colorAtObject(&o, tuple.NewPoint(4, 2, 6))
+
+	assert.Equal(t, floatcolor.Red, c)
+	assert.Equal(t, tuple.NewPoint(4, 2, 6), got.World)
+	assert.Equal(t, tuple.NewPoint(2, 1, 3), got.Object)
+	assert.Equal(t, tuple.NewPoint(1, 1, 3), got.Local)
+	assert.False(t, got.HasUV)
+}
+
+func TestFuncPatternReceivesUV(t *testing.T) {
+	o := uvObject{dummyObject(matrix.Identity4())}
+	var got PatternPoint
+	p := NewFuncPattern(func(p PatternPoint) floatcolor.Float64Color {
+		got = p
+		return floatcolor.Black
+	})
+
+	p.colorAtObject(o, tuple.NewPoint(3, 5, 0))
+
+	assert.True(t, got.HasUV)
+	assert.Equal(t, 0.3, got.U)
+	assert.Equal(t, 0.5, got.V)
+}
+
+func TestNestedFuncPatternReceivesUVOfObject(t *testing.T) {
+	o := uvObject{dummyObject(matrix.Identity4())}
+	uvPattern := NewFuncPattern(func(p PatternPoint) floatcolor.Float64Color {
+		return floatcolor.New(p.U, p.V, 0)
+	})
+	p := NewNestedStripePattern(uvPattern, SolidPattern(floatcolor.Black)).
+		WithTransform(transform.Scaling(10, 10, 10))
+
+	assert.Equal(t, floatcolor.New(0.3, 0.5, 0), p.colorAtObject(o, tuple.NewPoint(3, 5, 0)))
+}
+
+func TestFuncPatternCanEvaluateOtherPatterns(t *testing.T) {
+	o := dummyObject(matrix.Identity4())
+	stripes := NewStripePattern(floatcolor.White, floatcolor.Black)
+	inverted := NewFuncPattern(func(p PatternPoint) floatcolor.Float64Color {
+		return floatcolor.White.Sub(p.ColorOf(stripes))
+	}).WithTransform(transform.Scaling(0.5, 0.5, 0.5))
+
+	assert.Equal(t, floatcolor.Black, inverted.colorAtObject(&o, tuple.NewPoint(0.25, 0, 0)))
+	assert.Equal(t, floatcolor.White, inverted.colorAtObject(&o, tuple.NewPoint(0.75, 0, 0)))
+}
@@ -0,0 +1,38 @@
+package material
+
+import (
+	"image"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/image/texture"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// ImagePattern wraps an image around an object using its texture coordinates, with u running
+// from the left of the image to the right and v from the bottom to the top. The image repeats
+// outside the range 0 to 1. Objects without texture coordinates are colored black.
+type ImagePattern struct {
+	img image.Image
+}
+
+func NewImagePattern(img image.Image) ImagePattern {
+	return ImagePattern{img}
+}
+
+func (i ImagePattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	u, v, ok := uvAt(object, worldPoint)
+	if !ok {
+		return floatcolor.Black
+	}
+	return i.colorAtUV(u, v)
+}
+
+// colorAtUV blends the four pixels nearest to u and v.
+func (i ImagePattern) colorAtUV(u, v float64) floatcolor.Float64Color {
+	return texture.Bilinear(i.img, u, 1-v, texture.Repeat, texture.Repeat)
+}
+
+func (ImagePattern) inverseTransform() matrix.Matrix {
+	return matrix.Identity4()
+}
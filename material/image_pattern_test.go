@@ -0,0 +1,46 @@
+package material
+
+import (
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/canvas"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func quadrantImage() canvas.Canvas {
+	img := canvas.New(2, 2)
+	img.WritePixel(0, 0, floatcolor.Red)
+	img.WritePixel(1, 0, floatcolor.Green)
+	img.WritePixel(0, 1, floatcolor.Blue)
+	img.WritePixel(1, 1, floatcolor.White)
+	return img
+}
+
+func TestImagePatternMapsUVToPixels(t *testing.T) {
+	p := NewImagePattern(quadrantImage())
+
+	test.AssertAlmost(t, floatcolor.Red, p.colorAtUV(0.25, 0.75))
+	test.AssertAlmost(t, floatcolor.Green, p.colorAtUV(0.75, 0.75))
+	test.AssertAlmost(t, floatcolor.Blue, p.colorAtUV(0.25, 0.25))
+	test.AssertAlmost(t, floatcolor.White, p.colorAtUV(0.75, 0.25))
+}
+
+func TestImagePatternBlendsAndRepeats(t *testing.T) {
+	p := NewImagePattern(quadrantImage())
+
+	test.AssertAlmost(t, floatcolor.New(0.5, 0.5, 0), p.colorAtUV(0.5, 0.75))
+	test.AssertAlmost(t, floatcolor.New(0.5, 0, 0.5), p.colorAtUV(0.25, 0.5))
+	test.AssertAlmost(t, floatcolor.Red, p.colorAtUV(1.25, -0.25))
+}
+
+func TestImagePatternUsesObjectUV(t *testing.T) {
+	o := uvObject{dummyObject(matrix.Identity4())}
+	p := NewImagePattern(quadrantImage())
+
+	test.AssertAlmost(t, floatcolor.Green, p.colorAtObject(o, tuple.NewPoint(7.5, 7.5, 0)))
+	assert.Equal(t, floatcolor.Black, p.colorAtObject(obj, tuple.NewPoint(7.5, 7.5, 0)))
+}
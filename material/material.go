@@ -10,10 +10,29 @@ import (
 
 var Default = New(SolidPattern(floatcolor.White), 0.1, 0.9, 0.9, 200.0, 0, 0, 1)
 
+// defaultRoughnessSamples is the number of rays traced through the reflection and refraction
+// lobes of a rough material unless overridden with WithRoughnessSamples.
+const defaultRoughnessSamples = 8
+
 type Material struct {
 	pattern Pattern
 	ambient, diffuse, specular, shininess,
 	reflective, transparency, refractiveIndex float64
+	roughness        float64
+	roughnessSamples int
+	model            Model
+	metallic         float64
+
+	absorptionColor   floatcolor.Float64Color
+	absorptionDensity float64
+
+	cauchyB float64
+
+	volumeDensity float64
+
+	perturbation Perturbation
+
+	channels [channelCount]Pattern
 }
 
 func New(
@@ -26,14 +45,15 @@ func New(
 	transparency,
 	refractiveIndex float64) Material {
 	return Material{
-		pattern,
-		ambient,
-		diffuse,
-		specular,
-		shininess,
-		reflective,
-		transparency,
-		refractiveIndex,
+		pattern:          pattern,
+		ambient:          ambient,
+		diffuse:          diffuse,
+		specular:         specular,
+		shininess:        shininess,
+		reflective:       reflective,
+		transparency:     transparency,
+		refractiveIndex:  refractiveIndex,
+		roughnessSamples: defaultRoughnessSamples,
 	}
 }
 
@@ -65,10 +85,80 @@ func (m Material) RefractiveIndex() float64 {
 	return m.refractiveIndex
 }
 
+// Roughness returns how far reflected and refracted rays stray from the perfectly specular
+// direction. Zero gives a perfect mirror or clear glass.
+func (m Material) Roughness() float64 {
+	return m.roughness
+}
+
+// RoughnessSamples returns the number of rays traced through the reflection or refraction lobe
+// of a rough material.
+func (m Material) RoughnessSamples() int {
+	return m.roughnessSamples
+}
+
+// Model returns the reflectance model used by Lighting.
+func (m Material) Model() Model {
+	return m.model
+}
+
+// Metallic returns how metallic the surface is under the MetallicRoughness model, from 0 for a
+// dielectric to 1 for a metal.
+func (m Material) Metallic() float64 {
+	return m.metallic
+}
+
+// AbsorptionColor returns the color that light takes on after travelling one unit through the
+// material at an absorption density of 1.
+func (m Material) AbsorptionColor() floatcolor.Float64Color {
+	return m.absorptionColor
+}
+
+// AbsorptionDensity returns how strongly the material absorbs light travelling through it.
+func (m Material) AbsorptionDensity() float64 {
+	return m.absorptionDensity
+}
+
+// Transmittance returns the fraction of each color channel of light that survives travelling
+// distance units through the material, according to the Beer-Lambert law.
+func (m Material) Transmittance(distance float64) floatcolor.Float64Color {
+	if m.absorptionDensity == 0 {
+		return floatcolor.White
+	}
+	absorbance := floatcolor.White.Sub(m.absorptionColor).Mul(m.absorptionDensity * distance)
+	r, g, b := absorbance.RGB()
+	return floatcolor.New(math.Exp(-r), math.Exp(-g), math.Exp(-b))
+}
+
+// VolumeDensity returns how much light is scattered per unit distance travelled through the
+// material. A material with a non-zero density describes a participating medium such as smoke
+// or murky water rather than a surface.
+func (m Material) VolumeDensity() float64 {
+	return m.volumeDensity
+}
+
+func (m Material) Perturbation() Perturbation {
+	return m.perturbation
+}
+
+// PerturbNormal returns the normal used to shade the surface at worldPoint, given its true
+// normal there.
+func (m Material) PerturbNormal(object Object, worldPoint, normal tuple.Tuple) tuple.Tuple {
+	if m.perturbation == nil {
+		return normal
+	}
+	return m.perturbation.perturbNormal(object, worldPoint, normal)
+}
+
 func (m Material) Pattern() Pattern {
 	return m.pattern
 }
 
+// ColorAt returns the color of the material's pattern at a point in world space on or in object.
+func (m Material) ColorAt(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	return m.pattern.colorAtObject(object, worldPoint)
+}
+
 func (m Material) WithColor(color floatcolor.Float64Color) Material {
 	c := m.copy()
 	c.pattern = SolidPattern(color)
@@ -117,6 +207,68 @@ func (m Material) WithRefractiveIndex(r float64) Material {
 	return c
 }
 
+// WithRoughness sets the roughness used to scatter reflected and refracted rays, producing
+// brushed metal and frosted glass. The rays follow the same GGX distribution of microfacets as
+// the highlights of the MetallicRoughness model. Useful values are between 0 and 1.
+func (m Material) WithRoughness(r float64) Material {
+	c := m.copy()
+	c.roughness = r
+	return c
+}
+
+// WithRoughnessSamples sets the number of rays traced through the reflection or refraction lobe
+// of a rough material. More samples give smoother results at the cost of render time.
+func (m Material) WithRoughnessSamples(n int) Material {
+	if n < 1 {
+		panic("roughness samples must be at least 1")
+	}
+	c := m.copy()
+	c.roughnessSamples = n
+	return c
+}
+
+// WithModel sets the reflectance model used by Lighting.
+func (m Material) WithModel(model Model) Material {
+	c := m.copy()
+	c.model = model
+	return c
+}
+
+// WithMetallic sets how metallic the surface is under the MetallicRoughness model.
+func (m Material) WithMetallic(metallic float64) Material {
+	c := m.copy()
+	c.metallic = metallic
+	return c
+}
+
+// WithAbsorption makes light travelling through the material take on the given color, more
+// strongly the thicker the material and the higher the density. This is used to tint glass and
+// liquids by depth and has no effect on opaque materials.
+func (m Material) WithAbsorption(color floatcolor.Float64Color, density float64) Material {
+	c := m.copy()
+	c.absorptionColor = color
+	c.absorptionDensity = density
+	return c
+}
+
+// WithVolumeDensity turns the material into a constant-density participating medium filling the
+// object it's applied to. The object's surface is no longer shaded; instead light is scattered
+// throughout its interior, tinted by the pattern color. The ambient constant gives the amount
+// of light scattered without any direct lighting.
+func (m Material) WithVolumeDensity(d float64) Material {
+	c := m.copy()
+	c.volumeDensity = d
+	return c
+}
+
+// WithPerturbation sets a bump or normal map changing the normals used to shade the surface.
+// Nil removes it.
+func (m Material) WithPerturbation(p Perturbation) Material {
+	c := m.copy()
+	c.perturbation = p
+	return c
+}
+
 func (m Material) WithPattern(p Pattern) Material {
 	c := m.copy()
 	c.pattern = p
@@ -124,16 +276,19 @@ func (m Material) WithPattern(p Pattern) Material {
 }
 
 func (m Material) copy() Material {
-	return Material{
-		m.pattern,
-		m.ambient,
-		m.diffuse,
-		m.specular,
-		m.shininess,
-		m.reflective,
-		m.transparency,
-		m.refractiveIndex,
+	// Material is passed by value so the receiver is already a copy.
+	return m
+}
+
+// EnvironmentLighting returns the light diffusely reflected at position, given the average
+// radiance arriving from the surrounding environment over the hemisphere above the surface.
+func (m Material) EnvironmentLighting(object Object, position tuple.Tuple, radiance floatcolor.Float64Color) floatcolor.Float64Color {
+	m = m.At(object, position)
+	reflected := m.pattern.colorAtObject(object, position).Hadamard(radiance)
+	if m.model == MetallicRoughness {
+		return reflected.Mul(1 - m.metallic)
 	}
+	return reflected.Mul(m.diffuse)
 }
 
 func (m Material) Lighting(
@@ -144,6 +299,11 @@ func (m Material) Lighting(
 	normalv tuple.Tuple,
 	inShadow bool,
 ) floatcolor.Float64Color {
+	m = m.At(object, position)
+	if m.model == MetallicRoughness {
+		return m.microfacetLighting(object, light, position, eyev, normalv, inShadow)
+	}
+
 	effectiveColor := m.pattern.colorAtObject(object, position).Hadamard(light.Intensity())
 	ambient := effectiveColor.Mul(m.ambient)
 	if inShadow {
@@ -151,7 +311,7 @@ func (m Material) Lighting(
 	}
 
 	// Direction to the light source
-	lightv := light.Position().Sub(position).Norm()
+	lightv := light.DirectionFrom(position)
 
 	diffuse := floatcolor.Black
 	specular := floatcolor.Black
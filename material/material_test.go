@@ -7,6 +7,7 @@ import (
 	"github.com/danieltmartin/ray-tracer/floatcolor"
 	"github.com/danieltmartin/ray-tracer/light"
 	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/test"
 	"github.com/danieltmartin/ray-tracer/tuple"
 	"github.com/stretchr/testify/assert"
 )
@@ -24,6 +25,17 @@ func TestDefaultMaterial(t *testing.T) {
 	assert.Equal(t, 0.0, m.reflective)
 	assert.Equal(t, 0.0, m.transparency)
 	assert.Equal(t, 1.0, m.refractiveIndex)
+	assert.Equal(t, 0.0, m.Roughness())
+	assert.Equal(t, defaultRoughnessSamples, m.RoughnessSamples())
+}
+
+func TestWithRoughnessDoesNotModifyOriginal(t *testing.T) {
+	m := Default.WithRoughness(0.4).WithRoughnessSamples(3)
+
+	assert.Equal(t, 0.4, m.Roughness())
+	assert.Equal(t, 3, m.RoughnessSamples())
+	assert.Equal(t, 0.0, Default.Roughness())
+	assert.Equal(t, defaultRoughnessSamples, Default.RoughnessSamples())
 }
 
 func TestLightingEyeBetweenLightAndSurface(t *testing.T) {
@@ -39,6 +51,19 @@ func TestLightingEyeBetweenLightAndSurface(t *testing.T) {
 	assert.Equal(t, floatcolor.New(1.9, 1.9, 1.9), color)
 }
 
+func TestLightingWithDirectionalLight(t *testing.T) {
+	m := Default
+	position := tuple.NewPoint(3, 4, 5)
+
+	eyev := tuple.NewVector(0, 0, -1)
+	normalv := tuple.NewVector(0, 0, -1)
+	light := light.NewDirectionalLight(tuple.NewVector(0, 0, 1), floatcolor.White)
+
+	color := m.Lighting(obj, light, position, eyev, normalv, false)
+
+	assert.Equal(t, floatcolor.New(1.9, 1.9, 1.9), color)
+}
+
 func TestLightingEyeOffset45Degrees(t *testing.T) {
 	m := Default
 	position := tuple.NewPoint(0, 0, 0)
@@ -122,3 +147,45 @@ func TestLightingWithStripePattern(t *testing.T) {
 	assert.Equal(t, floatcolor.New(1, 1, 1), color1)
 	assert.Equal(t, floatcolor.New(0, 0, 0), color2)
 }
+
+func TestTransmittanceWithoutAbsorption(t *testing.T) {
+	assert.Equal(t, floatcolor.White, Default.Transmittance(100))
+}
+
+func TestTransmittanceFollowsBeerLambertLaw(t *testing.T) {
+	m := Default.WithAbsorption(floatcolor.New(1, 0.5, 0), 2)
+
+	test.AssertAlmost(t, floatcolor.White, m.Transmittance(0))
+	test.AssertAlmost(t, floatcolor.New(1, math.Exp(-1), math.Exp(-2)), m.Transmittance(1))
+	test.AssertAlmost(t, floatcolor.New(1, math.Exp(-3), math.Exp(-6)), m.Transmittance(3))
+}
+
+func TestVolumeDensity(t *testing.T) {
+	m := Default.WithVolumeDensity(0.3)
+
+	assert.Equal(t, 0.0, Default.VolumeDensity())
+	assert.Equal(t, 0.3, m.VolumeDensity())
+}
+
+func TestColorAtEvaluatesPattern(t *testing.T) {
+	m := Default.WithPattern(NewStripePattern(floatcolor.White, floatcolor.Black))
+
+	assert.Equal(t, floatcolor.White, m.ColorAt(obj, tuple.NewPoint(0.5, 0, 0)))
+	assert.Equal(t, floatcolor.Black, m.ColorAt(obj, tuple.NewPoint(1.5, 0, 0)))
+}
+
+func TestEnvironmentLightingScalesRadianceByDiffuse(t *testing.T) {
+	m := Default.WithColor(floatcolor.New(1, 0.5, 0)).WithDiffuse(0.5)
+
+	c := m.EnvironmentLighting(obj, tuple.NewPoint(0, 0, 0), floatcolor.New(0.4, 0.4, 1))
+
+	test.AssertAlmost(t, floatcolor.New(0.2, 0.1, 0), c)
+}
+
+func TestEnvironmentLightingOfMetalsIsReflectedNotDiffused(t *testing.T) {
+	m := Default.WithModel(MetallicRoughness).WithMetallic(1)
+
+	c := m.EnvironmentLighting(obj, tuple.NewPoint(0, 0, 0), floatcolor.White)
+
+	test.AssertAlmost(t, floatcolor.Black, c)
+}
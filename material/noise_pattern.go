@@ -0,0 +1,186 @@
+package material
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// noisePatternOctaves is the number of octaves of noise used by patterns that don't take it as
+// a parameter.
+const noisePatternOctaves = 6
+
+// FBMPattern blends between two colors using fractional Brownian motion, giving soft cloudy
+// variation.
+type FBMPattern struct {
+	color1, color2 floatcolor.Float64Color
+	octaves        int
+	patternTransform
+}
+
+// NewFBMPattern creates an FBMPattern summing the given number of octaves of noise. More
+// octaves add finer detail.
+func NewFBMPattern(color1, color2 floatcolor.Float64Color, octaves int) FBMPattern {
+	if octaves < 1 {
+		panic("noise octaves must be at least 1")
+	}
+	return FBMPattern{color1, color2, octaves, patternTransform(matrix.Identity4())}
+}
+
+func (f FBMPattern) WithTransform(transform matrix.Matrix) FBMPattern {
+	return FBMPattern{f.color1, f.color2, f.octaves, patternTransform(transform.Inverse())}
+}
+
+func (f FBMPattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+	t := (fbm(point.X, point.Y, point.Z, f.octaves) + 1) / 2
+	return floatcolor.Lerp(f.color1, f.color2, t)
+}
+
+func (f FBMPattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	return f.colorAt(toPatternPoint(f, object, worldPoint))
+}
+
+// TurbulencePattern blends between two colors using turbulence, giving billowing variation
+// with sharp creases in the first color.
+type TurbulencePattern struct {
+	color1, color2 floatcolor.Float64Color
+	octaves        int
+	patternTransform
+}
+
+// NewTurbulencePattern creates a TurbulencePattern summing the given number of octaves of
+// noise.
+func NewTurbulencePattern(color1, color2 floatcolor.Float64Color, octaves int) TurbulencePattern {
+	if octaves < 1 {
+		panic("noise octaves must be at least 1")
+	}
+	return TurbulencePattern{color1, color2, octaves, patternTransform(matrix.Identity4())}
+}
+
+func (p TurbulencePattern) WithTransform(transform matrix.Matrix) TurbulencePattern {
+	return TurbulencePattern{p.color1, p.color2, p.octaves, patternTransform(transform.Inverse())}
+}
+
+func (p TurbulencePattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+	// Turbulence rarely approaches 1, so stretch it to use the full range of colors.
+	t := math.Min(1, 2*turbulence(point.X, point.Y, point.Z, p.octaves))
+	return floatcolor.Lerp(p.color1, p.color2, t)
+}
+
+func (p TurbulencePattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	return p.colorAt(toPatternPoint(p, object, worldPoint))
+}
+
+// MarblePattern has veins of the second color running through the first, parallel to the y-z
+// plane and two units apart, distorted by turbulence.
+type MarblePattern struct {
+	color1, color2 floatcolor.Float64Color
+	distortion     float64
+	patternTransform
+}
+
+// NewMarblePattern creates a MarblePattern whose veins are displaced by up to distortion units.
+// Zero gives straight veins.
+func NewMarblePattern(color1, color2 floatcolor.Float64Color, distortion float64) MarblePattern {
+	return MarblePattern{color1, color2, distortion, patternTransform(matrix.Identity4())}
+}
+
+func (m MarblePattern) WithTransform(transform matrix.Matrix) MarblePattern {
+	return MarblePattern{m.color1, m.color2, m.distortion, patternTransform(transform.Inverse())}
+}
+
+func (m MarblePattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+	x := point.X + m.distortion*turbulence(point.X, point.Y, point.Z, noisePatternOctaves)
+	t := (1 + math.Sin(math.Pi*x)) / 2
+	return floatcolor.Lerp(m.color1, m.color2, t)
+}
+
+func (m MarblePattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	return m.colorAt(toPatternPoint(m, object, worldPoint))
+}
+
+// WoodPattern has growth rings around the y axis like RingPattern, blending from the first
+// color to the second across each ring, with the rings distorted by noise.
+type WoodPattern struct {
+	color1, color2 floatcolor.Float64Color
+	distortion     float64
+	patternTransform
+}
+
+// NewWoodPattern creates a WoodPattern whose rings are displaced by up to distortion units.
+// Zero gives perfectly circular rings.
+func NewWoodPattern(color1, color2 floatcolor.Float64Color, distortion float64) WoodPattern {
+	return WoodPattern{color1, color2, distortion, patternTransform(matrix.Identity4())}
+}
+
+func (w WoodPattern) WithTransform(transform matrix.Matrix) WoodPattern {
+	return WoodPattern{w.color1, w.color2, w.distortion, patternTransform(transform.Inverse())}
+}
+
+func (w WoodPattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+	d := math.Sqrt(point.X*point.X+point.Z*point.Z) +
+		w.distortion*fbm(point.X, point.Y, point.Z, noisePatternOctaves)
+	return floatcolor.Lerp(w.color1, w.color2, d-math.Floor(d))
+}
+
+func (w WoodPattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	return w.colorAt(toPatternPoint(w, object, worldPoint))
+}
+
+// GranitePattern is a fine speckle of the second color over the first.
+type GranitePattern struct {
+	color1, color2 floatcolor.Float64Color
+	patternTransform
+}
+
+func NewGranitePattern(color1, color2 floatcolor.Float64Color) GranitePattern {
+	return GranitePattern{color1, color2, patternTransform(matrix.Identity4())}
+}
+
+func (g GranitePattern) WithTransform(transform matrix.Matrix) GranitePattern {
+	return GranitePattern{g.color1, g.color2, patternTransform(transform.Inverse())}
+}
+
+func (g GranitePattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+	// High frequency turbulence, thresholded to give distinct grains.
+	t := turbulence(8*point.X, 8*point.Y, 8*point.Z, noisePatternOctaves)
+	t = math.Max(0, math.Min(1, 4*t-0.5))
+	return floatcolor.Lerp(g.color1, g.color2, t)
+}
+
+func (g GranitePattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	return g.colorAt(toPatternPoint(g, object, worldPoint))
+}
+
+// PerturbedPattern jitters the point at which another pattern is evaluated using noise, giving
+// regular patterns such as stripes or checkers an organic, hand-drawn look.
+type PerturbedPattern struct {
+	pattern Pattern
+	scale   float64
+	patternTransform
+}
+
+// NewPerturbedPattern creates a PerturbedPattern that moves points by up to scale units before
+// evaluating pattern at them.
+func NewPerturbedPattern(pattern Pattern, scale float64) PerturbedPattern {
+	return PerturbedPattern{pattern, scale, patternTransform(matrix.Identity4())}
+}
+
+// WithTransform transforms the noise used to jitter points. The wrapped pattern keeps its own
+// transform, applied after jittering.
+func (p PerturbedPattern) WithTransform(transform matrix.Matrix) PerturbedPattern {
+	return PerturbedPattern{p.pattern, p.scale, patternTransform(transform.Inverse())}
+}
+
+func (p PerturbedPattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
+	point := toPatternPoint(p, object, worldPoint)
+	// Offset the noise for each axis so they jitter independently.
+	jitter := tuple.NewVector(
+		noise(point.X, point.Y, point.Z),
+		noise(point.X+31.4, point.Y+15.9, point.Z+26.5),
+		noise(point.X+89.7, point.Y+93.2, point.Z+38.4))
+	objectPoint := object.WorldPointToLocal(worldPoint).Add(jitter.Mul(p.scale))
+	return nestedColorAt(p.pattern, object, worldPoint, objectPoint)
+}
@@ -0,0 +1,114 @@
+package material
+
+import (
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFBMPatternIsMidwayAtLatticePoints(t *testing.T) {
+	p := NewFBMPattern(floatcolor.White, floatcolor.Black, 4)
+
+	test.AssertAlmost(t, floatcolor.Lerp(floatcolor.White, floatcolor.Black, 0.5), p.colorAt(tuple.NewPoint(1, 2, 3)))
+}
+
+func TestTurbulencePatternIsFirstColorAtLatticePoints(t *testing.T) {
+	p := NewTurbulencePattern(floatcolor.White, floatcolor.Black, 4)
+
+	test.AssertAlmost(t, floatcolor.Lerp(floatcolor.White, floatcolor.Black, 0), p.colorAt(tuple.NewPoint(1, 2, 3)))
+}
+
+func TestNoisePatternsPanicWithoutOctaves(t *testing.T) {
+	assert.Panics(t, func() { NewFBMPattern(floatcolor.White, floatcolor.Black, 0) })
+	assert.Panics(t, func() { NewTurbulencePattern(floatcolor.White, floatcolor.Black, 0) })
+}
+
+func TestMarblePatternWithoutDistortionHasStraightVeins(t *testing.T) {
+	p := NewMarblePattern(floatcolor.White, floatcolor.Black, 0)
+
+	test.AssertAlmost(t, floatcolor.Black, p.colorAt(tuple.NewPoint(0.5, 0, 0)))
+	test.AssertAlmost(t, floatcolor.Black, p.colorAt(tuple.NewPoint(0.5, 3.7, -1.2)))
+	test.AssertAlmost(t, floatcolor.Lerp(floatcolor.White, floatcolor.Black, 0), p.colorAt(tuple.NewPoint(1.5, 0, 0)))
+}
+
+func TestMarblePatternDistortionBendsVeins(t *testing.T) {
+	straight := NewMarblePattern(floatcolor.White, floatcolor.Black, 0)
+	distorted := NewMarblePattern(floatcolor.White, floatcolor.Black, 2)
+	point := tuple.NewPoint(0.3, 0.6, 0.2)
+
+	assert.NotEqual(t, straight.colorAt(point), distorted.colorAt(point))
+}
+
+func TestWoodPatternWithoutDistortionHasCircularRings(t *testing.T) {
+	p := NewWoodPattern(floatcolor.White, floatcolor.Black, 0)
+
+	ringStart := floatcolor.Lerp(floatcolor.White, floatcolor.Black, 0)
+	test.AssertAlmost(t, ringStart, p.colorAt(tuple.NewPoint(1, 0, 0)))
+	test.AssertAlmost(t, ringStart, p.colorAt(tuple.NewPoint(0, 5, 2)))
+	test.AssertAlmost(t, p.colorAt(tuple.NewPoint(0.5, 0, 0)), p.colorAt(tuple.NewPoint(0, 0, 1.5)))
+}
+
+func TestGranitePatternStaysBetweenColors(t *testing.T) {
+	p := NewGranitePattern(floatcolor.Black, floatcolor.White)
+	sawSpeck := false
+	for i := 0; i < 200; i++ {
+		r, g, b := p.colorAt(tuple.NewPoint(float64(i)*0.07, 0.3, 0.9)).RGB()
+		assert.InDelta(t, 0.5, r, 0.5+1e-9)
+		assert.InDelta(t, 0.5, g, 0.5+1e-9)
+		assert.InDelta(t, 0.5, b, 0.5+1e-9)
+		if r > 0.5 {
+			sawSpeck = true
+		}
+	}
+	assert.True(t, sawSpeck)
+}
+
+func TestNoisePatternWithTransform(t *testing.T) {
+	o := dummyObject(matrix.Identity4())
+	p := NewMarblePattern(floatcolor.White, floatcolor.Black, 0).
+		WithTransform(transform.Scaling(2, 2, 2))
+
+	test.AssertAlmost(t, floatcolor.Black, p.colorAtObject(&o, tuple.NewPoint(1, 0, 0)))
+}
+
+func TestPerturbedPatternWithoutScaleMatchesPattern(t *testing.T) {
+	o := dummyObject(matrix.Identity4())
+	stripes := NewStripePattern(floatcolor.White, floatcolor.Black)
+	p := NewPerturbedPattern(stripes, 0)
+
+	for _, x := range []float64{-1.5, -0.5, 0.1, 0.9, 1.2} {
+		point := tuple.NewPoint(x, 0.3, 0.7)
+		assert.Equal(t, stripes.colorAtObject(&o, point), p.colorAtObject(&o, point))
+	}
+}
+
+func TestPerturbedPatternJittersPoints(t *testing.T) {
+	o := dummyObject(matrix.Identity4())
+	stripes := NewStripePattern(floatcolor.White, floatcolor.Black)
+	p := NewPerturbedPattern(stripes, 0.5)
+
+	differences := 0
+	for i := 0; i < 100; i++ {
+		point := tuple.NewPoint(float64(i)*0.1+0.05, 0.3, 0.7)
+		if p.colorAtObject(&o, point) != stripes.colorAtObject(&o, point) {
+			differences++
+		}
+	}
+
+	assert.Greater(t, differences, 0)
+	assert.Less(t, differences, 100)
+}
+
+func TestPerturbedPatternAppliesObjectAndNestedTransforms(t *testing.T) {
+	o := dummyObject(transform.Scaling(2, 2, 2).Inverse())
+	stripes := NewStripePattern(floatcolor.White, floatcolor.Black).
+		WithTransform(transform.Translation(0.5, 0, 0))
+	p := NewPerturbedPattern(stripes, 0)
+
+	assert.Equal(t, floatcolor.White, p.colorAtObject(&o, tuple.NewPoint(2.5, 0, 0)))
+}
@@ -0,0 +1,52 @@
+package material
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNoiseIsZeroAtLatticePoints(t *testing.T) {
+	assert.Equal(t, 0.0, noise(0, 0, 0))
+	assert.Equal(t, 0.0, noise(3, -7, 12))
+	assert.Equal(t, 0.0, noise(-256, 255, 1))
+}
+
+func TestNoiseIsBoundedAndVaries(t *testing.T) {
+	distinct := map[float64]bool{}
+	for i := 0; i < 1000; i++ {
+		x, y, z := float64(i)*0.37, float64(i)*0.11-20, float64(i)*0.73+5
+		n := noise(x, y, z)
+		assert.LessOrEqual(t, math.Abs(n), 1.0)
+		distinct[n] = true
+	}
+	assert.Greater(t, len(distinct), 900)
+}
+
+func TestNoiseIsContinuous(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		x := float64(i) * 0.13
+		assert.InDelta(t, noise(x, 0.5, 0.25), noise(x+1e-6, 0.5, 0.25), 1e-4)
+	}
+}
+
+func TestNoiseRepeatsEvery256Units(t *testing.T) {
+	assert.InDelta(t, noise(1.3, 2.7, 3.1), noise(257.3, 2.7, 3.1), 1e-9)
+}
+
+func TestFBMAndTurbulenceAreNormalized(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		x, y, z := float64(i)*0.29, float64(i)*0.53, float64(i)*-0.17
+		f := fbm(x, y, z, 5)
+		assert.LessOrEqual(t, math.Abs(f), 1.0)
+		tb := turbulence(x, y, z, 5)
+		assert.GreaterOrEqual(t, tb, 0.0)
+		assert.LessOrEqual(t, tb, 1.0)
+	}
+}
+
+func TestFBMWithOneOctaveIsNoise(t *testing.T) {
+	assert.Equal(t, noise(0.3, 1.7, 2.2), fbm(0.3, 1.7, 2.2, 1))
+	assert.Equal(t, math.Abs(noise(0.3, 1.7, 2.2)), turbulence(0.3, 1.7, 2.2, 1))
+}
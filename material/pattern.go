@@ -30,43 +30,65 @@ func (s SolidPattern) inverseTransform() matrix.Matrix {
 }
 
 type StripePattern struct {
-	color1, color2 floatcolor.Float64Color
+	pattern1, pattern2 Pattern
 	patternTransform
 }
 
 func NewStripePattern(color1, color2 floatcolor.Float64Color) StripePattern {
-	return StripePattern{color1, color2, patternTransform(matrix.Identity4())}
+	return NewNestedStripePattern(SolidPattern(color1), SolidPattern(color2))
+}
+
+// NewNestedStripePattern creates stripes alternating between two patterns. The patterns are
+// evaluated in the stripe pattern's space, so are transformed by it as well as their own
+// transforms.
+func NewNestedStripePattern(pattern1, pattern2 Pattern) StripePattern {
+	return StripePattern{pattern1, pattern2, patternTransform(matrix.Identity4())}
 }
 
 func (s StripePattern) WithTransform(transform matrix.Matrix) StripePattern {
-	return StripePattern{s.color1, s.color2, patternTransform(transform.Inverse())}
+	return StripePattern{s.pattern1, s.pattern2, patternTransform(transform.Inverse())}
 }
 
-func (p StripePattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+func (p StripePattern) pick(point tuple.Tuple) Pattern {
 	if int(math.Floor(point.X))%2 == 0 {
-		return p.color1
+		return p.pattern1
 	}
-	return p.color2
+	return p.pattern2
+}
+
+func (p StripePattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+	return nestedColorAt(p.pick(point), nil, point, point)
 }
 
 func (p StripePattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
-	return p.colorAt(toPatternPoint(p, object, worldPoint))
+	point := toPatternPoint(p, object, worldPoint)
+	return nestedColorAt(p.pick(point), object, worldPoint, point)
 }
 
 type GradientPattern struct {
-	fromColor, toColor floatcolor.Float64Color
+	from, to Pattern
 	patternTransform
 }
 
 func NewGradientPattern(fromColor, toColor floatcolor.Float64Color) GradientPattern {
-	return GradientPattern{fromColor, toColor, patternTransform(matrix.Identity4())}
+	return NewNestedGradientPattern(SolidPattern(fromColor), SolidPattern(toColor))
+}
+
+// NewNestedGradientPattern creates a gradient blending from one pattern to another. The
+// patterns are evaluated in the gradient pattern's space.
+func NewNestedGradientPattern(from, to Pattern) GradientPattern {
+	return GradientPattern{from, to, patternTransform(matrix.Identity4())}
 }
 
 func (g GradientPattern) WithTransform(transform matrix.Matrix) GradientPattern {
-	return GradientPattern{g.fromColor, g.toColor, patternTransform(transform.Inverse())}
+	return GradientPattern{g.from, g.to, patternTransform(transform.Inverse())}
 }
 
 func (s GradientPattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+	return s.colorAtPoint(nil, point, point)
+}
+
+func (s GradientPattern) colorAtPoint(object Object, worldPoint, point tuple.Tuple) floatcolor.Float64Color {
 	absX := math.Abs(point.X)
 	floorX := math.Floor(absX)
 	fraction := absX - floorX
@@ -75,62 +97,85 @@ func (s GradientPattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
 		// a hard transition from the end color back to the beginning color.
 		fraction = 1 - fraction
 	}
-	return floatcolor.Lerp(s.fromColor, s.toColor, fraction)
+	from := nestedColorAt(s.from, object, worldPoint, point)
+	to := nestedColorAt(s.to, object, worldPoint, point)
+	return floatcolor.Lerp(from, to, fraction)
 }
 
 func (p GradientPattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
-	return p.colorAt(toPatternPoint(p, object, worldPoint))
+	return p.colorAtPoint(object, worldPoint, toPatternPoint(p, object, worldPoint))
 }
 
 type RingPattern struct {
-	fromColor, toColor floatcolor.Float64Color
+	pattern1, pattern2 Pattern
 	patternTransform
 }
 
 func NewRingPattern(fromColor, toColor floatcolor.Float64Color) RingPattern {
-	return RingPattern{fromColor, toColor, patternTransform(matrix.Identity4())}
+	return NewNestedRingPattern(SolidPattern(fromColor), SolidPattern(toColor))
+}
+
+// NewNestedRingPattern creates rings alternating between two patterns. The patterns are
+// evaluated in the ring pattern's space.
+func NewNestedRingPattern(pattern1, pattern2 Pattern) RingPattern {
+	return RingPattern{pattern1, pattern2, patternTransform(matrix.Identity4())}
 }
 
 func (r RingPattern) WithTransform(transform matrix.Matrix) RingPattern {
-	return RingPattern{r.fromColor, r.toColor, patternTransform(transform.Inverse())}
+	return RingPattern{r.pattern1, r.pattern2, patternTransform(transform.Inverse())}
 }
 
-func (r RingPattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+func (r RingPattern) pick(point tuple.Tuple) Pattern {
 	d := math.Sqrt(point.X*point.X + point.Z*point.Z)
 	if int(math.Floor(d))%2 == 0 {
-		return r.fromColor
+		return r.pattern1
 	}
-	return r.toColor
+	return r.pattern2
+}
+
+func (r RingPattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+	return nestedColorAt(r.pick(point), nil, point, point)
 }
 
 func (r RingPattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
-	return r.colorAt(toPatternPoint(r, object, worldPoint))
+	point := toPatternPoint(r, object, worldPoint)
+	return nestedColorAt(r.pick(point), object, worldPoint, point)
 }
 
 type CheckerPattern struct {
-	color1, color2 floatcolor.Float64Color
+	pattern1, pattern2 Pattern
 	patternTransform
 }
 
 func NewCheckerPattern(color1, color2 floatcolor.Float64Color) CheckerPattern {
-	return CheckerPattern{color1, color2, patternTransform(matrix.Identity4())}
+	return NewNestedCheckerPattern(SolidPattern(color1), SolidPattern(color2))
+}
+
+// NewNestedCheckerPattern creates checks alternating between two patterns. The patterns are
+// evaluated in the checker pattern's space.
+func NewNestedCheckerPattern(pattern1, pattern2 Pattern) CheckerPattern {
+	return CheckerPattern{pattern1, pattern2, patternTransform(matrix.Identity4())}
 }
 
 func (r CheckerPattern) WithTransform(transform matrix.Matrix) CheckerPattern {
-	return CheckerPattern{r.color1, r.color2, patternTransform(transform.Inverse())}
+	return CheckerPattern{r.pattern1, r.pattern2, patternTransform(transform.Inverse())}
 }
 
-func (r CheckerPattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+func (r CheckerPattern) pick(point tuple.Tuple) Pattern {
 	x, y, z, _ := point.XYZW()
 	if int(math.Floor(x)+math.Floor(y)+math.Floor(z))%2 == 0 {
-		return r.color1
+		return r.pattern1
 	}
-	return r.color2
+	return r.pattern2
+}
+
+func (r CheckerPattern) colorAt(point tuple.Tuple) floatcolor.Float64Color {
+	return nestedColorAt(r.pick(point), nil, point, point)
 }
 
 func (r CheckerPattern) colorAtObject(object Object, worldPoint tuple.Tuple) floatcolor.Float64Color {
 	patternPoint := toPatternPoint(r, object, worldPoint)
-	return r.colorAt(patternPoint)
+	return nestedColorAt(r.pick(patternPoint), object, worldPoint, patternPoint)
 }
 
 // TestPattern returns colors with the RGB values set to the XYZ of the point of intersection.
@@ -153,3 +198,20 @@ func toPatternPoint(pattern Pattern, object Object, worldPoint tuple.Tuple) tupl
 	objectPoint := object.WorldPointToLocal(worldPoint)
 	return pattern.inverseTransform().MulTuple(objectPoint)
 }
+
+// nestedColorAt evaluates a pattern nested within another at point, which is in the outer
+// pattern's space.
+func nestedColorAt(pattern Pattern, object Object, worldPoint, point tuple.Tuple) floatcolor.Float64Color {
+	return pattern.colorAtObject(patternSpace{object, point}, worldPoint)
+}
+
+// patternSpace is the object passed to a nested pattern, which has already found the point
+// within the object at which to evaluate the nested pattern.
+type patternSpace struct {
+	Object
+	point tuple.Tuple
+}
+
+func (p patternSpace) WorldPointToLocal(tuple.Tuple) tuple.Tuple {
+	return p.point
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/danieltmartin/ray-tracer/floatcolor"
 	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/test"
 	"github.com/danieltmartin/ray-tracer/transform"
 	"github.com/danieltmartin/ray-tracer/tuple"
 	"github.com/stretchr/testify/assert"
@@ -135,3 +136,40 @@ type dummyObject matrix.Matrix
 func (d dummyObject) WorldPointToLocal(p tuple.Tuple) tuple.Tuple {
 	return matrix.Matrix(d).MulTuple(p)
 }
+
+func TestStripesOfCheckers(t *testing.T) {
+	o := dummyObject(matrix.Identity4())
+	checkers := NewCheckerPattern(floatcolor.Red, floatcolor.Green).
+		WithTransform(transform.Scaling(0.5, 0.5, 0.5))
+	p := NewNestedStripePattern(checkers, SolidPattern(floatcolor.Blue))
+
+	assert.Equal(t, floatcolor.Red, p.colorAtObject(&o, tuple.NewPoint(0.25, 0, 0)))
+	assert.Equal(t, floatcolor.Green, p.colorAtObject(&o, tuple.NewPoint(0.75, 0, 0)))
+	assert.Equal(t, floatcolor.Blue, p.colorAtObject(&o, tuple.NewPoint(1.25, 0, 0)))
+}
+
+func TestNestedPatternIsTransformedByParentAndItself(t *testing.T) {
+	o := dummyObject(transform.Scaling(2, 2, 2).Inverse())
+	inner := NewStripePattern(floatcolor.Red, floatcolor.Green).
+		WithTransform(transform.Translation(0.5, 0, 0))
+	p := NewNestedCheckerPattern(inner, SolidPattern(floatcolor.Blue)).
+		WithTransform(transform.Scaling(0.5, 0.5, 0.5))
+
+	// Object space x = 0.5, checker space x = 1, which is in the second check.
+	assert.Equal(t, floatcolor.Blue, p.colorAtObject(&o, tuple.NewPoint(1, 0.1, 0.1)))
+	// Object space x = 1.125, checker space x = 2.25, stripe space x = 1.75.
+	assert.Equal(t, floatcolor.Green, p.colorAtObject(&o, tuple.NewPoint(2.25, 0.1, 0.1)))
+	// Object space x = 1.375, checker space x = 2.75, stripe space x = 2.25.
+	assert.Equal(t, floatcolor.Red, p.colorAtObject(&o, tuple.NewPoint(2.75, 0.1, 0.1)))
+}
+
+func TestNestedRingAndGradientPatterns(t *testing.T) {
+	o := dummyObject(matrix.Identity4())
+	rings := NewNestedRingPattern(SolidPattern(floatcolor.Red), NewStripePattern(floatcolor.White, floatcolor.Black))
+	gradient := NewNestedGradientPattern(SolidPattern(floatcolor.Black), rings)
+
+	assert.Equal(t, floatcolor.Red, rings.colorAtObject(&o, tuple.NewPoint(0.5, 0, 0)))
+	assert.Equal(t, floatcolor.Black, rings.colorAtObject(&o, tuple.NewPoint(-0.5, 0, 1.5)))
+	assert.Equal(t, floatcolor.White, rings.colorAtObject(&o, tuple.NewPoint(0.5, 0, 1.5)))
+	test.AssertAlmost(t, floatcolor.Lerp(floatcolor.Black, floatcolor.Red, 0.5), gradient.colorAtObject(&o, tuple.NewPoint(0.5, 0, 0)))
+}
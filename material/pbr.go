@@ -0,0 +1,127 @@
+package material
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/light"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Model selects the reflectance model a material uses for direct lighting.
+type Model int
+
+const (
+	// Phong is the classic model driven by the ambient, diffuse, specular and shininess constants.
+	Phong Model = iota
+
+	// MetallicRoughness is a physically based microfacet model driven by the pattern color
+	// (albedo), Metallic and Roughness. It uses the GGX normal distribution, Smith shadowing and
+	// Schlick's Fresnel approximation. The base reflectance of dielectrics is derived from the
+	// refractive index, so a typical value such as 1.5 should be set for non-metals.
+	MetallicRoughness
+)
+
+// minMicrofacetRoughness avoids the singularity in the GGX distribution of a perfectly smooth
+// surface.
+const minMicrofacetRoughness = 0.03
+
+func (m Material) microfacetLighting(
+	object Object,
+	light light.PointLight,
+	position tuple.Tuple,
+	eyev tuple.Tuple,
+	normalv tuple.Tuple,
+	inShadow bool,
+) floatcolor.Float64Color {
+	albedo := m.pattern.colorAtObject(object, position)
+	ambient := albedo.Hadamard(light.Intensity()).Mul(m.ambient)
+	if inShadow {
+		return ambient
+	}
+
+	lightv := light.DirectionFrom(position)
+	normalDotLight := normalv.Dot(lightv)
+	normalDotEye := normalv.Dot(eyev)
+	if normalDotLight <= 0 || normalDotEye <= 0 {
+		return ambient
+	}
+
+	halfv := lightv.Add(eyev).Norm()
+	normalDotHalf := math.Max(normalv.Dot(halfv), 0)
+	halfDotEye := math.Min(math.Max(halfv.Dot(eyev), 0), 1)
+
+	roughness := math.Max(m.roughness, minMicrofacetRoughness)
+	distribution := ggxDistribution(normalDotHalf, roughness)
+	geometry := smithGeometry(normalDotEye, normalDotLight, roughness)
+	fresnel := fresnelSchlick(halfDotEye, m.baseReflectance(albedo))
+
+	specular := fresnel.Mul(distribution * geometry / (4 * normalDotEye * normalDotLight))
+
+	// Light that isn't reflected at the surface is available for diffuse scattering, except in
+	// metals which absorb it.
+	diffuseWeight := floatcolor.White.Sub(fresnel).Mul(1 - m.metallic)
+	diffuse := diffuseWeight.Hadamard(albedo).Mul(1 / math.Pi)
+
+	// The outgoing radiance is scaled by π so that, as with the Phong model, a white light shining
+	// directly on a white diffuse surface produces white.
+	return ambient.Add(diffuse.Add(specular).Hadamard(light.Intensity()).Mul(normalDotLight * math.Pi))
+}
+
+// baseReflectance returns the reflectance at normal incidence. Dielectrics derive it from their
+// refractive index while metals tint it with their albedo.
+func (m Material) baseReflectance(albedo floatcolor.Float64Color) floatcolor.Float64Color {
+	r0 := (m.refractiveIndex - 1) / (m.refractiveIndex + 1)
+	r0 *= r0
+	dielectric := floatcolor.New(r0, r0, r0)
+	return dielectric.Mul(1 - m.metallic).Add(albedo.Mul(m.metallic))
+}
+
+// ggxAlpha maps roughness to the width of the GGX distribution, so that roughness changes the
+// look of a surface evenly.
+func ggxAlpha(roughness float64) float64 {
+	return roughness * roughness
+}
+
+// ggxDistribution computes the Trowbridge-Reitz (GGX) normal distribution function, which gives
+// the proportion of microfacets aligned with the half vector.
+func ggxDistribution(normalDotHalf, roughness float64) float64 {
+	alpha := ggxAlpha(roughness)
+	alpha2 := alpha * alpha
+	d := normalDotHalf*normalDotHalf*(alpha2-1) + 1
+	return alpha2 / (math.Pi * d * d)
+}
+
+// smithGeometry computes Smith's shadowing-masking function using the Schlick-GGX approximation
+// for each of the view and light directions.
+func smithGeometry(normalDotEye, normalDotLight, roughness float64) float64 {
+	k := (roughness + 1) * (roughness + 1) / 8
+	schlickGGX := func(cos float64) float64 {
+		return cos / (cos*(1-k) + k)
+	}
+	return schlickGGX(normalDotEye) * schlickGGX(normalDotLight)
+}
+
+// fresnelSchlick computes Schlick's approximation of the Fresnel reflectance for each color channel.
+func fresnelSchlick(cos float64, r0 floatcolor.Float64Color) floatcolor.Float64Color {
+	x := 1 - cos
+	x5 := x * x * x * x * x
+	return r0.Add(floatcolor.White.Sub(r0).Mul(x5))
+}
+
+// SampleMicrofacetNormal picks the normal of a microfacet on a surface with the given normal and
+// roughness, from the GGX distribution that the MetallicRoughness model uses, with a probability
+// in proportion to the area of the surface the microfacet covers. u1 and u2 are independent
+// random numbers from 0 to 1.
+func SampleMicrofacetNormal(normal tuple.Tuple, roughness, u1, u2 float64) tuple.Tuple {
+	alpha := ggxAlpha(roughness)
+	cos2Theta := (1 - u1) / (u1*(alpha*alpha-1) + 1)
+	cosTheta := math.Sqrt(cos2Theta)
+	sinTheta := math.Sqrt(math.Max(0, 1-cos2Theta))
+	phi := 2 * math.Pi * u2
+	s, t := orthonormalBasis(normal)
+	return s.Mul(sinTheta * math.Cos(phi)).
+		Add(t.Mul(sinTheta * math.Sin(phi))).
+		Add(normal.Mul(cosTheta)).
+		Norm()
+}
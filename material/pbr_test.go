@@ -0,0 +1,111 @@
+package material
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/light"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultMaterialUsesPhong(t *testing.T) {
+	assert.Equal(t, Phong, Default.Model())
+	assert.Equal(t, 0.0, Default.Metallic())
+}
+
+func TestMicrofacetLightingEyeBetweenLightAndSurface(t *testing.T) {
+	m := Default.
+		WithModel(MetallicRoughness).
+		WithRoughness(0.5).
+		WithRefractiveIndex(1.5)
+	position := tuple.NewPoint(0, 0, 0)
+	eyev := tuple.NewVector(0, 0, -1)
+	normalv := tuple.NewVector(0, 0, -1)
+	light := light.NewPointLight(tuple.NewPoint(0, 0, -10), floatcolor.White)
+
+	color := m.Lighting(obj, light, position, eyev, normalv, false)
+
+	// ambient + (1 - F0) diffuse + F0 * D specular with F0 = 0.04 and D = 1/(π * 0.5^4)
+	expected := 0.1 + 0.96 + 0.04*(1/(math.Pi*0.0625))/4*math.Pi
+	test.AssertAlmost(t, floatcolor.New(expected, expected, expected), color)
+}
+
+func TestMicrofacetLightingWithLightBehindSurface(t *testing.T) {
+	m := Default.WithModel(MetallicRoughness).WithRoughness(0.5)
+	position := tuple.NewPoint(0, 0, 0)
+	eyev := tuple.NewVector(0, 0, -1)
+	normalv := tuple.NewVector(0, 0, -1)
+	light := light.NewPointLight(tuple.NewPoint(0, 0, 10), floatcolor.White)
+
+	color := m.Lighting(obj, light, position, eyev, normalv, false)
+
+	test.AssertAlmost(t, floatcolor.New(0.1, 0.1, 0.1), color)
+}
+
+func TestMicrofacetLightingWithSurfaceInShadow(t *testing.T) {
+	m := Default.WithModel(MetallicRoughness).WithRoughness(0.5)
+	position := tuple.NewPoint(0, 0, 0)
+	eyev := tuple.NewVector(0, 0, -1)
+	normalv := tuple.NewVector(0, 0, -1)
+	light := light.NewPointLight(tuple.NewPoint(0, 0, -10), floatcolor.White)
+
+	color := m.Lighting(obj, light, position, eyev, normalv, true)
+
+	test.AssertAlmost(t, floatcolor.New(0.1, 0.1, 0.1), color)
+}
+
+func TestMicrofacetLightingMetalHasNoDiffuseAndTintedSpecular(t *testing.T) {
+	m := Default.
+		WithModel(MetallicRoughness).
+		WithColor(floatcolor.Red).
+		WithAmbient(0).
+		WithMetallic(1).
+		WithRoughness(0.5)
+	position := tuple.NewPoint(0, 0, 0)
+	eyev := tuple.NewVector(0, math.Sqrt2/2, -math.Sqrt2/2)
+	normalv := tuple.NewVector(0, 0, -1)
+	light := light.NewPointLight(tuple.NewPoint(0, 10, -10), floatcolor.White)
+
+	color := m.Lighting(obj, light, position, eyev, normalv, false)
+
+	r, g, b := color.RGB()
+	assert.Greater(t, r, 0.0)
+	assert.InDelta(t, 0.0, g, 1e-9)
+	assert.InDelta(t, 0.0, b, 1e-9)
+}
+
+func TestMicrofacetRougherSurfaceHasDimmerHighlight(t *testing.T) {
+	position := tuple.NewPoint(0, 0, 0)
+	eyev := tuple.NewVector(0, 0, -1)
+	normalv := tuple.NewVector(0, 0, -1)
+	light := light.NewPointLight(tuple.NewPoint(0, 0, -10), floatcolor.White)
+	smooth := Default.WithModel(MetallicRoughness).WithMetallic(1).WithRoughness(0.2)
+	rough := smooth.WithRoughness(0.8)
+
+	smoothColor := smooth.Lighting(obj, light, position, eyev, normalv, false)
+	roughColor := rough.Lighting(obj, light, position, eyev, normalv, false)
+
+	smoothR, _, _ := smoothColor.RGB()
+	roughR, _, _ := roughColor.RGB()
+	assert.Greater(t, smoothR, roughR)
+}
+
+func TestFresnelSchlickAtGrazingAngleIsFullyReflective(t *testing.T) {
+	f := fresnelSchlick(0, floatcolor.New(0.04, 0.04, 0.04))
+
+	test.AssertAlmost(t, floatcolor.White, f)
+}
+
+func TestSampleMicrofacetNormal(t *testing.T) {
+	normal := tuple.NewVector(0, 1, 0)
+
+	test.AssertAlmost(t, normal, SampleMicrofacetNormal(normal, 1, 0, 0.3))
+	// With the widest distribution, half the surface faces within 45° of the normal.
+	test.AssertAlmost(t, math.Cos(math.Pi/4), SampleMicrofacetNormal(normal, 1, 0.5, 0.3).Dot(normal))
+	// Smoother surfaces have microfacets closer to the normal.
+	assert.Greater(t, SampleMicrofacetNormal(normal, 0.1, 0.99, 0.3).Dot(normal), 0.99)
+	test.AssertAlmost(t, 1.0, SampleMicrofacetNormal(normal, 0.5, 0.7, 0.8).Mag())
+}
@@ -0,0 +1,132 @@
+package material
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// perturbationDelta is the distance between the points sampled to find how a bump or normal
+// map changes across a surface.
+const perturbationDelta = 1e-4
+
+// Perturbation changes the normal used to shade a surface, giving the appearance of detail such
+// as ripples or embossing without changing the geometry.
+type Perturbation interface {
+	perturbNormal(object Object, worldPoint, normal tuple.Tuple) tuple.Tuple
+}
+
+// BumpMap treats the brightness of a pattern as the height of the surface above its true
+// position, tilting normals to match the slope of the pattern.
+type BumpMap struct {
+	pattern Pattern
+	scale   float64
+}
+
+// NewBumpMap creates a BumpMap where a white pattern raises the surface by scale units more than
+// a black one. Negative scales give indentations.
+func NewBumpMap(pattern Pattern, scale float64) BumpMap {
+	return BumpMap{pattern, scale}
+}
+
+func (b BumpMap) perturbNormal(object Object, worldPoint, normal tuple.Tuple) tuple.Tuple {
+	height := func(dx, dy, dz float64) float64 {
+		p := worldPoint.Add(tuple.NewVector(dx, dy, dz))
+		r, g, bl := b.pattern.colorAtObject(object, p).RGB()
+		return (r + g + bl) / 3
+	}
+	gradient := tuple.NewVector(
+		height(perturbationDelta, 0, 0)-height(-perturbationDelta, 0, 0),
+		height(0, perturbationDelta, 0)-height(0, -perturbationDelta, 0),
+		height(0, 0, perturbationDelta)-height(0, 0, -perturbationDelta),
+	).Mul(1 / (2 * perturbationDelta))
+
+	// Only the slope along the surface tilts the normal.
+	surfaceGradient := gradient.Sub(normal.Mul(gradient.Dot(normal)))
+	return normal.Sub(surfaceGradient.Mul(b.scale)).Norm()
+}
+
+// NormalMap replaces normals with ones read from a pattern, usually an ImagePattern of a
+// tangent-space normal map, whose red, green and blue encode the normal's components along the
+// direction of increasing u, increasing v, and the true normal. It needs texture coordinates
+// and leaves normals unchanged on objects without them.
+type NormalMap struct {
+	pattern  Pattern
+	strength float64
+}
+
+// NewNormalMap creates a NormalMap from pattern. A strength of 1 uses the normals as given,
+// while smaller values flatten and larger values exaggerate them.
+func NewNormalMap(pattern Pattern, strength float64) NormalMap {
+	return NormalMap{pattern, strength}
+}
+
+func (n NormalMap) perturbNormal(object Object, worldPoint, normal tuple.Tuple) tuple.Tuple {
+	tangent, bitangent, ok := tangentFrame(object, worldPoint, normal)
+	if !ok {
+		return normal
+	}
+	r, g, b := n.pattern.colorAtObject(object, worldPoint).RGB()
+	x, y, z := (2*r-1)*n.strength, (2*g-1)*n.strength, 2*b-1
+	perturbed := tangent.Mul(x).Add(bitangent.Mul(y)).Add(normal.Mul(z))
+	if perturbed.Mag() == 0 {
+		return normal
+	}
+	return perturbed.Norm()
+}
+
+// tangentFrame returns unit vectors perpendicular to normal pointing in the directions of
+// increasing u and v texture coordinates of object at worldPoint.
+func tangentFrame(object Object, worldPoint, normal tuple.Tuple) (tangent, bitangent tuple.Tuple, ok bool) {
+	u0, v0, ok := uvAt(object, worldPoint)
+	if !ok {
+		return tuple.Tuple{}, tuple.Tuple{}, false
+	}
+
+	// Find how texture coordinates change along two directions across the surface, then solve
+	// for the direction in which only u increases.
+	s, t := orthonormalBasis(normal)
+	duds, dvds := uvChange(object, worldPoint, s, u0, v0)
+	dudt, dvdt := uvChange(object, worldPoint, t, u0, v0)
+	det := duds*dvdt - dudt*dvds
+	if math.Abs(det) < 1e-12 {
+		return tuple.Tuple{}, tuple.Tuple{}, false
+	}
+	tangent = s.Mul(dvdt / det).Sub(t.Mul(dvds / det)).Norm()
+	dPdv := t.Mul(duds / det).Sub(s.Mul(dudt / det))
+
+	bitangent = normal.Cross(tangent)
+	if bitangent.Dot(dPdv) < 0 {
+		bitangent = bitangent.Neg()
+	}
+	return tangent, bitangent, true
+}
+
+// uvChange returns the rate of change of texture coordinates along direction from worldPoint.
+func uvChange(object Object, worldPoint, direction tuple.Tuple, u0, v0 float64) (du, dv float64) {
+	u, v, _ := uvAt(object, worldPoint.Add(direction.Mul(perturbationDelta)))
+	return unwrap(u-u0) / perturbationDelta, unwrap(v-v0) / perturbationDelta
+}
+
+// unwrap corrects a difference in texture coordinates that crosses the seam where they wrap
+// from 1 back to 0.
+func unwrap(d float64) float64 {
+	if d > 0.5 {
+		return d - 1
+	}
+	if d < -0.5 {
+		return d + 1
+	}
+	return d
+}
+
+// orthonormalBasis returns two unit vectors perpendicular to each other and to n.
+func orthonormalBasis(n tuple.Tuple) (s, t tuple.Tuple) {
+	helper := tuple.NewVector(1, 0, 0)
+	if math.Abs(n.X) > 0.9 {
+		helper = tuple.NewVector(0, 1, 0)
+	}
+	s = helper.Cross(n).Norm()
+	t = n.Cross(s)
+	return s, t
+}
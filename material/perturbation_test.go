@@ -0,0 +1,83 @@
+package material
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaterialWithoutPerturbationKeepsNormal(t *testing.T) {
+	n := tuple.NewVector(0, 1, 0)
+
+	assert.Equal(t, n, Default.PerturbNormal(obj, tuple.NewPoint(1, 2, 3), n))
+}
+
+func TestBumpMapOfConstantPatternKeepsNormal(t *testing.T) {
+	m := Default.WithPerturbation(NewBumpMap(SolidPattern(floatcolor.White), 2))
+
+	test.AssertAlmost(t, tuple.NewVector(0, 1, 0), m.PerturbNormal(obj, tuple.NewPoint(1, 0, 3), tuple.NewVector(0, 1, 0)))
+}
+
+func TestBumpMapTiltsNormalAwayFromSlope(t *testing.T) {
+	ramp := NewFuncPattern(func(p PatternPoint) floatcolor.Float64Color {
+		return floatcolor.New(p.Local.X, p.Local.X, p.Local.X)
+	})
+	m := Default.WithPerturbation(NewBumpMap(ramp, 0.5))
+
+	n := m.PerturbNormal(obj, tuple.NewPoint(0.3, 0, 0.2), tuple.NewVector(0, 1, 0))
+
+	test.AssertAlmost(t, tuple.NewVector(-0.5, 1, 0).Norm(), n)
+}
+
+func TestBumpMapIgnoresSlopeAlongNormal(t *testing.T) {
+	ramp := NewFuncPattern(func(p PatternPoint) floatcolor.Float64Color {
+		return floatcolor.New(p.Local.Y, p.Local.Y, p.Local.Y)
+	})
+	m := Default.WithPerturbation(NewBumpMap(ramp, 0.5))
+
+	test.AssertAlmost(t, tuple.NewVector(0, 1, 0), m.PerturbNormal(obj, tuple.NewPoint(0.3, 0.5, 0.2), tuple.NewVector(0, 1, 0)))
+}
+
+func TestNormalMapOfFlatColorKeepsNormal(t *testing.T) {
+	o := uvObject{dummyObject(matrix.Identity4())}
+	m := Default.WithPerturbation(NewNormalMap(SolidPattern(floatcolor.New(0.5, 0.5, 1)), 1))
+
+	test.AssertAlmost(t, tuple.NewVector(0, 0, 1), m.PerturbNormal(o, tuple.NewPoint(3, 5, 0), tuple.NewVector(0, 0, 1)))
+}
+
+func TestNormalMapTiltsNormalAlongTextureDirections(t *testing.T) {
+	o := uvObject{dummyObject(matrix.Identity4())}
+	towardsU := Default.WithPerturbation(NewNormalMap(SolidPattern(floatcolor.New(1, 0.5, 1)), 1))
+	towardsV := Default.WithPerturbation(NewNormalMap(SolidPattern(floatcolor.New(0.5, 1, 1)), 1))
+	point := tuple.NewPoint(3, 5, 0)
+	normal := tuple.NewVector(0, 0, 1)
+
+	test.AssertAlmost(t, tuple.NewVector(math.Sqrt2/2, 0, math.Sqrt2/2), towardsU.PerturbNormal(o, point, normal))
+	test.AssertAlmost(t, tuple.NewVector(0, math.Sqrt2/2, math.Sqrt2/2), towardsV.PerturbNormal(o, point, normal))
+}
+
+func TestNormalMapStrengthScalesTilt(t *testing.T) {
+	o := uvObject{dummyObject(matrix.Identity4())}
+	m := Default.WithPerturbation(NewNormalMap(SolidPattern(floatcolor.New(1, 0.5, 1)), 0.5))
+
+	n := m.PerturbNormal(o, tuple.NewPoint(3, 5, 0), tuple.NewVector(0, 0, 1))
+
+	test.AssertAlmost(t, tuple.NewVector(0.5, 0, 1).Norm(), n)
+}
+
+func TestNormalMapWithoutTextureCoordinatesKeepsNormal(t *testing.T) {
+	m := Default.WithPerturbation(NewNormalMap(SolidPattern(floatcolor.New(1, 0.5, 1)), 1))
+
+	assert.Equal(t, tuple.NewVector(0, 0, 1), m.PerturbNormal(obj, tuple.NewPoint(3, 5, 0), tuple.NewVector(0, 0, 1)))
+}
+
+func TestUnwrapCorrectsDifferencesAcrossSeam(t *testing.T) {
+	assert.InDelta(t, 0.02, unwrap(0.02), 1e-12)
+	assert.InDelta(t, -0.02, unwrap(0.98), 1e-12)
+	assert.InDelta(t, 0.02, unwrap(-0.98), 1e-12)
+}
@@ -89,6 +89,21 @@ func (m Matrix) Mul(m2 Matrix) Matrix {
 	return product
 }
 
+// Lerp interpolates linearly between each element of m and m2, returning m when t is 0 and m2
+// when t is 1.
+func (m Matrix) Lerp(m2 Matrix, t float64) Matrix {
+	if len(m.m) != len(m2.m) || len(m.m[0]) != len(m2.m[0]) {
+		panic("invalid dimensions for matrix interpolation")
+	}
+	result := New(len(m.m[0]), len(m.m))
+	for y := range result.m {
+		for x := range result.m[y] {
+			result.m[y][x] = m.m[y][x] + (m2.m[y][x]-m.m[y][x])*t
+		}
+	}
+	return result
+}
+
 func (m Matrix) MulTuple(t tuple.Tuple) tuple.Tuple {
 	if len(m.m) != 4 || len(m.m[0]) != 4 {
 		panic("invalid dimensions for matrix-tuple multiplication")
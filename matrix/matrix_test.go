@@ -407,3 +407,18 @@ func TestCopy(t *testing.T) {
 
 	assert.Equal(t, a, a.Copy())
 }
+
+func TestLerp(t *testing.T) {
+	a := NewFromSlice([][]float64{
+		{1, 2},
+		{3, 4},
+	})
+	b := NewFromSlice([][]float64{
+		{3, 2},
+		{-1, 8},
+	})
+
+	assert.Equal(t, a, a.Lerp(b, 0))
+	assert.Equal(t, b, a.Lerp(b, 1))
+	assert.Equal(t, NewFromSlice([][]float64{{1.5, 2}, {2, 5}}), a.Lerp(b, 0.25))
+}
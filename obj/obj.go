@@ -12,22 +12,49 @@ import (
 )
 
 type Parser struct {
-	rootGroup    *primitive.Group
-	currentGroup *primitive.Group
-	vertices     []tuple.Tuple
-	normals      []tuple.Tuple
+	rootGroup     *primitive.Group
+	currentGroup  *primitive.Group
+	vertices      []tuple.Tuple
+	normals       []tuple.Tuple
+	textureCoords []primitive.TextureCoord
+	// faces holds the triangles of each group, starting with the root group, which become a
+	// mesh in that group once the whole file has been read.
+	faces []groupFaces
+}
+
+type groupFaces struct {
+	group *primitive.Group
+	faces []primitive.MeshFace
 }
 
 func newParser() Parser {
 	group := primitive.NewGroup()
-	return Parser{group, group, nil, nil}
+	return Parser{group, group, nil, nil, nil, []groupFaces{{group, nil}}}
 }
 
+// Parse reads an obj file, returning a group holding a group with a Mesh for each named group,
+// followed by a Mesh of any faces outside a named group. The meshes share the file's vertices,
+// normals and texture coordinates.
 func Parse(r io.Reader) (primitive.Primitive, error) {
 	parsed, err := parse(r)
-	return parsed.rootGroup, err
+	if err != nil {
+		return parsed.rootGroup, err
+	}
+	parsed.addMeshes()
+	return parsed.rootGroup, nil
+}
+
+// addMeshes adds a Mesh of the faces of each group to it.
+func (p *Parser) addMeshes() {
+	for _, g := range p.faces {
+		if len(g.faces) > 0 {
+			g.group.Add(primitive.NewMesh(p.vertices, p.normals, p.textureCoords, g.faces))
+		}
+	}
 }
 
+// parse reads an obj file into groups and their faces, leaving the caller to turn the faces
+// into meshes.
 func parse(r io.Reader) (Parser, error) {
 	file := newParser()
 	sc := bufio.NewScanner(r)
@@ -58,12 +85,15 @@ func (p *Parser) parseLine(tokens []string) error {
 		err = p.parseVertex(tokens[1:])
 	case "vn":
 		err = p.parseNormal(tokens[1:])
+	case "vt":
+		err = p.parseTextureCoord(tokens[1:])
 	case "f":
 		err = p.parseFace(tokens[1:])
 	case "g":
 		group := primitive.NewGroup()
 		p.rootGroup.Add(group)
 		p.currentGroup = group
+		p.faces = append(p.faces, groupFaces{group, nil})
 	}
 	return err
 }
@@ -114,8 +144,27 @@ func (p *Parser) parseNormal(tokens []string) error {
 	return nil
 }
 
+func (p *Parser) parseTextureCoord(tokens []string) error {
+	if len(tokens) < 2 {
+		return fmt.Errorf("expected at least 2 arguments for texture coordinate")
+	}
+
+	u, err := strconv.ParseFloat(tokens[0], 64)
+	if err != nil {
+		return fmt.Errorf("bad float: %v", err)
+	}
+	v, err := strconv.ParseFloat(tokens[1], 64)
+	if err != nil {
+		return fmt.Errorf("bad float: %v", err)
+	}
+
+	p.textureCoords = append(p.textureCoords, primitive.TextureCoord{U: u, V: v})
+
+	return nil
+}
+
 type vertex struct {
-	vertexIndex, normalIndex int
+	vertexIndex, textureIndex, normalIndex int
 }
 
 func (p *Parser) parseFace(tokens []string) error {
@@ -134,20 +183,33 @@ func (p *Parser) parseFace(tokens []string) error {
 		if err != nil {
 			return fmt.Errorf("bad vertex reference: %v", err)
 		}
-		if int(v) > len(p.vertices) {
+		if v == 0 || int(v) > len(p.vertices) {
 			return fmt.Errorf("vertex does not exist")
 		}
 
 		var vertex vertex
 		vertex.vertexIndex = int(v)
+		vertex.textureIndex = -1
 		vertex.normalIndex = -1
 
+		if len(tokens) >= 2 && tokens[1] != "" {
+			t, err := strconv.ParseUint(tokens[1], 10, 64)
+			if err != nil {
+				return fmt.Errorf("bad texture coordinate reference: %v", err)
+			}
+			// Some files refer to texture coordinates they don't define, so these are ignored
+			// rather than rejected.
+			if t > 0 && int(t) <= len(p.textureCoords) {
+				vertex.textureIndex = int(t)
+			}
+		}
+
 		if len(tokens) >= 3 {
 			n, err := strconv.ParseUint(tokens[2], 10, 64)
 			if err != nil {
 				return fmt.Errorf("bad normal reference: %v", err)
 			}
-			if int(v) > len(p.vertices) {
+			if n == 0 || int(n) > len(p.normals) {
 				return fmt.Errorf("normal does not exist")
 			}
 			vertex.normalIndex = int(n)
@@ -156,30 +218,39 @@ func (p *Parser) parseFace(tokens []string) error {
 		vertexReferences = append(vertexReferences, vertex)
 	}
 
-	triangles := p.triangulate(vertexReferences)
-	p.currentGroup.Add(triangles...)
+	current := &p.faces[len(p.faces)-1]
+	current.faces = append(current.faces, triangulate(vertexReferences)...)
 
 	return nil
 }
 
-func (p *Parser) triangulate(vertexReferences []vertex) []primitive.Primitive {
-	triangles := make([]primitive.Primitive, len(vertexReferences)-2)
+// triangulate splits a polygon into a fan of triangles around its first vertex. Normals and
+// texture coordinates are only kept if every vertex of the polygon has them.
+func triangulate(vertexReferences []vertex) []primitive.MeshFace {
+	hasNormals, hasTextureCoords := true, true
+	for _, v := range vertexReferences {
+		hasNormals = hasNormals && v.normalIndex != -1
+		hasTextureCoords = hasTextureCoords && v.textureIndex != -1
+	}
 
+	faces := make([]primitive.MeshFace, len(vertexReferences)-2)
 	for i := 1; i < len(vertexReferences)-1; i++ {
-		p1 := p.vertices[vertexReferences[0].vertexIndex-1]
-		p2 := p.vertices[vertexReferences[i].vertexIndex-1]
-		p3 := p.vertices[vertexReferences[i+1].vertexIndex-1]
-		if vertexReferences[0].normalIndex != -1 {
-			n1 := p.normals[vertexReferences[0].normalIndex-1]
-			n2 := p.normals[vertexReferences[i].normalIndex-1]
-			n3 := p.normals[vertexReferences[i+1].normalIndex-1]
-			tri := primitive.NewSmoothTriangle(p1, p2, p3, n1, n2, n3)
-			triangles[i-1] = &tri
-		} else {
-			tri := primitive.NewTriangle(p1, p2, p3)
-			triangles[i-1] = &tri
+		corners := [3]vertex{vertexReferences[0], vertexReferences[i], vertexReferences[i+1]}
+		face := primitive.MeshFace{
+			Normals:       [3]int{-1, -1, -1},
+			TextureCoords: [3]int{-1, -1, -1},
+		}
+		for k, c := range corners {
+			face.Vertices[k] = c.vertexIndex - 1
+			if hasNormals {
+				face.Normals[k] = c.normalIndex - 1
+			}
+			if hasTextureCoords {
+				face.TextureCoords[k] = c.textureIndex - 1
+			}
 		}
+		faces[i-1] = face
 	}
 
-	return triangles
+	return faces
 }
@@ -66,15 +66,18 @@ f 1 3 4
 	parsed, err := parse(strings.NewReader(file))
 
 	require.NoError(t, err)
+	parsed.addMeshes()
 	children := parsed.rootGroup.Children()
-	require.Len(t, children, 2)
+	require.Len(t, children, 1)
+	mesh := children[0].(*primitive.Mesh)
+	require.Equal(t, 2, mesh.FaceCount())
 
-	v1, v2, v3 := children[0].(*primitive.Triangle).Vertices()
+	v1, v2, v3 := mesh.FaceVertices(0)
 	assert.Equal(t, parsed.vertices[0], v1)
 	assert.Equal(t, parsed.vertices[1], v2)
 	assert.Equal(t, parsed.vertices[2], v3)
 
-	v1, v2, v3 = children[1].(*primitive.Triangle).Vertices()
+	v1, v2, v3 = mesh.FaceVertices(1)
 	assert.Equal(t, parsed.vertices[0], v1)
 	assert.Equal(t, parsed.vertices[2], v2)
 	assert.Equal(t, parsed.vertices[3], v3)
@@ -94,10 +97,13 @@ f 1 2 3 4 5
 	parsed, err := parse(strings.NewReader(file))
 
 	require.NoError(t, err)
+	parsed.addMeshes()
 	children := parsed.rootGroup.Children()
-	require.Len(t, children, 3)
+	require.Len(t, children, 1)
+	mesh := children[0].(*primitive.Mesh)
+	require.Equal(t, 3, mesh.FaceCount())
 
-	v1, v2, v3 := children[0].(*primitive.Triangle).Vertices()
+	v1, v2, v3 := mesh.FaceVertices(0)
 	assert.Equal(t, parsed.vertices[0], v1)
 	assert.Equal(t, parsed.vertices[1], v2)
 	assert.Equal(t, parsed.vertices[2], v3)
@@ -119,19 +125,20 @@ f 1 3 4
 	parsed, err := parse(strings.NewReader(file))
 
 	require.NoError(t, err)
+	parsed.addMeshes()
 	children := parsed.rootGroup.Children()
 	require.Len(t, children, 2)
 	g1 := children[0].(*primitive.Group)
 	g2 := children[1].(*primitive.Group)
-	t1 := g1.Children()[0].(*primitive.Triangle)
-	t2 := g2.Children()[0].(*primitive.Triangle)
+	m1 := g1.Children()[0].(*primitive.Mesh)
+	m2 := g2.Children()[0].(*primitive.Mesh)
 
-	v1, v2, v3 := t1.Vertices()
+	v1, v2, v3 := m1.FaceVertices(0)
 	assert.Equal(t, parsed.vertices[0], v1)
 	assert.Equal(t, parsed.vertices[1], v2)
 	assert.Equal(t, parsed.vertices[2], v3)
 
-	v1, v2, v3 = t2.Vertices()
+	v1, v2, v3 = m2.FaceVertices(0)
 	assert.Equal(t, parsed.vertices[0], v1)
 	assert.Equal(t, parsed.vertices[2], v2)
 	assert.Equal(t, parsed.vertices[3], v3)
@@ -168,20 +175,92 @@ f 1/0/3 2/102/1 3/14/2
 
 	parsed, err := parse(strings.NewReader(file))
 	require.NoError(t, err)
+	parsed.addMeshes()
 
-	g := parsed.rootGroup
-	t1 := g.Children()[0].(*primitive.SmoothTriangle)
-	t2 := g.Children()[1].(*primitive.SmoothTriangle)
+	mesh := parsed.rootGroup.Children()[0].(*primitive.Mesh)
 
-	v1, v2, v3 := t1.Vertices()
+	v1, v2, v3 := mesh.FaceVertices(0)
 	assert.Equal(t, parsed.vertices[0], v1)
 	assert.Equal(t, parsed.vertices[1], v2)
 	assert.Equal(t, parsed.vertices[2], v3)
 
-	n1, n2, n3 := t1.Normals()
+	n1, n2, n3, ok := mesh.FaceNormals(0)
+	require.True(t, ok)
 	assert.Equal(t, parsed.normals[2], n1)
 	assert.Equal(t, parsed.normals[0], n2)
 	assert.Equal(t, parsed.normals[1], n3)
 
-	assert.Equal(t, t2, t1)
+	assert.Equal(t, mesh.Face(0), mesh.Face(1))
+}
+
+func TestTextureCoordRecords(t *testing.T) {
+	file := `
+vt 0 0.5
+vt 1 0.25 0
+`
+
+	parsed, err := parse(strings.NewReader(file))
+
+	require.NoError(t, err)
+	assert.Equal(t, []primitive.TextureCoord{{U: 0, V: 0.5}, {U: 1, V: 0.25}}, parsed.textureCoords)
+}
+
+func TestFacesWithTextureCoords(t *testing.T) {
+	file := `
+v 0 1 0
+v -1 0 0
+v 1 0 0
+vt 0.5 1
+vt 0 0
+vt 1 0
+f 1/1 2/2 3/3
+f 1/1 2/2 3/4
+`
+
+	parsed, err := parse(strings.NewReader(file))
+	require.NoError(t, err)
+	parsed.addMeshes()
+
+	mesh := parsed.rootGroup.Children()[0].(*primitive.Mesh)
+	assert.Equal(t, [3]int{0, 1, 2}, mesh.Face(0).TextureCoords)
+	assert.Equal(t, [3]int{-1, -1, -1}, mesh.Face(0).Normals)
+	assert.Equal(t, [3]int{-1, -1, -1}, mesh.Face(1).TextureCoords)
+}
+
+func TestFaceReferencingMissingNormal(t *testing.T) {
+	file := `
+v 0 1 0
+v -1 0 0
+v 1 0 0
+vn 0 0 1
+f 1//1 2//1 3//2
+`
+
+	_, err := parse(strings.NewReader(file))
+
+	assert.EqualError(t, err, "line 6: normal does not exist")
+}
+
+func TestParseSharesVerticesBetweenGroupMeshes(t *testing.T) {
+	file := `
+v -1 1 0
+v -1 0 0
+v 1 0 0
+v 1 1 0
+f 1 2 3
+g Second
+f 1 3 4
+`
+
+	parsed, err := Parse(strings.NewReader(file))
+
+	require.NoError(t, err)
+	children := parsed.(*primitive.Group).Children()
+	require.Len(t, children, 2)
+	second := children[0].(*primitive.Group).Children()[0].(*primitive.Mesh)
+	root := children[1].(*primitive.Mesh)
+	assert.Equal(t, 1, root.FaceCount())
+	assert.Equal(t, 1, second.FaceCount())
+	_, _, v3 := second.FaceVertices(0)
+	assert.Equal(t, tuple.NewPoint(1, 1, 0), v3)
 }
@@ -0,0 +1,196 @@
+package obj
+
+import (
+	"io"
+
+	"github.com/danieltmartin/ray-tracer/primitive"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// ParseSubdivided reads an obj file like Parse, then smooths each group of faces with levels
+// rounds of Loop subdivision, which splits every triangle into four and moves the vertices
+// towards a smooth surface. Each group becomes a Mesh whose normals come from the subdivided
+// surface, so any normals and texture coordinates in the file are ignored. The groups are
+// arranged as Parse arranges them.
+func ParseSubdivided(r io.Reader, levels int) (primitive.Primitive, error) {
+	parsed, err := parse(r)
+	if err != nil {
+		return parsed.rootGroup, err
+	}
+
+	for _, g := range parsed.faces {
+		if len(g.faces) == 0 {
+			continue
+		}
+		triangles := make([][3]int, len(g.faces))
+		for f, face := range g.faces {
+			triangles[f] = face.Vertices
+		}
+		vertices, triangles := compact(parsed.vertices, triangles)
+		for l := 0; l < levels; l++ {
+			vertices, triangles = loopSubdivide(vertices, triangles)
+		}
+		g.group.Add(smoothMesh(vertices, triangles))
+	}
+	return parsed.rootGroup, nil
+}
+
+// compact returns only the vertices used by triangles, with the triangles renumbered to match.
+func compact(vertices []tuple.Tuple, triangles [][3]int) ([]tuple.Tuple, [][3]int) {
+	index := map[int]int{}
+	var used []tuple.Tuple
+	renumbered := make([][3]int, len(triangles))
+	for i, t := range triangles {
+		for k, v := range t {
+			n, ok := index[v]
+			if !ok {
+				n = len(used)
+				index[v] = n
+				used = append(used, vertices[v])
+			}
+			renumbered[i][k] = n
+		}
+	}
+	return used, renumbered
+}
+
+type edge struct {
+	a, b int
+}
+
+func newEdge(a, b int) edge {
+	if a > b {
+		a, b = b, a
+	}
+	return edge{a, b}
+}
+
+// loopSubdivide applies one round of Loop subdivision. Edges used by only one triangle are
+// treated as creases so that open meshes keep their outline.
+func loopSubdivide(vertices []tuple.Tuple, triangles [][3]int) ([]tuple.Tuple, [][3]int) {
+	// Find each edge, in a fixed order, and the vertices opposite it.
+	var edges []edge
+	edgeIndex := map[edge]int{}
+	var opposite [][]int
+	for _, t := range triangles {
+		for k := 0; k < 3; k++ {
+			e := newEdge(t[k], t[(k+1)%3])
+			i, ok := edgeIndex[e]
+			if !ok {
+				i = len(edges)
+				edgeIndex[e] = i
+				edges = append(edges, e)
+				opposite = append(opposite, nil)
+			}
+			opposite[i] = append(opposite[i], t[(k+2)%3])
+		}
+	}
+
+	neighbors := make([][]int, len(vertices))
+	boundaryNeighbors := make([][]int, len(vertices))
+	for i, e := range edges {
+		neighbors[e.a] = append(neighbors[e.a], e.b)
+		neighbors[e.b] = append(neighbors[e.b], e.a)
+		if len(opposite[i]) == 1 {
+			boundaryNeighbors[e.a] = append(boundaryNeighbors[e.a], e.b)
+			boundaryNeighbors[e.b] = append(boundaryNeighbors[e.b], e.a)
+		}
+	}
+
+	result := make([]tuple.Tuple, 0, len(vertices)+len(edges))
+	for v, p := range vertices {
+		result = append(result, smoothVertex(vertices, p, neighbors[v], boundaryNeighbors[v]))
+	}
+	for i, e := range edges {
+		a, b := vertices[e.a], vertices[e.b]
+		if len(opposite[i]) == 2 {
+			c, d := vertices[opposite[i][0]], vertices[opposite[i][1]]
+			result = append(result, weightedPoint(
+				[]tuple.Tuple{a, b, c, d}, []float64{3.0 / 8, 3.0 / 8, 1.0 / 8, 1.0 / 8}))
+		} else {
+			result = append(result, weightedPoint([]tuple.Tuple{a, b}, []float64{0.5, 0.5}))
+		}
+	}
+
+	subdivided := make([][3]int, 0, len(triangles)*4)
+	for _, t := range triangles {
+		ab := len(vertices) + edgeIndex[newEdge(t[0], t[1])]
+		bc := len(vertices) + edgeIndex[newEdge(t[1], t[2])]
+		ca := len(vertices) + edgeIndex[newEdge(t[2], t[0])]
+		subdivided = append(subdivided,
+			[3]int{t[0], ab, ca},
+			[3]int{t[1], bc, ab},
+			[3]int{t[2], ca, bc},
+			[3]int{ab, bc, ca},
+		)
+	}
+	return result, subdivided
+}
+
+// smoothVertex moves an existing vertex p towards its neighbors. Vertices on a boundary only
+// move along it, and corners where more than two boundary edges meet stay where they are.
+func smoothVertex(vertices []tuple.Tuple, p tuple.Tuple, neighbors, boundaryNeighbors []int) tuple.Tuple {
+	switch {
+	case len(boundaryNeighbors) == 2:
+		return weightedPoint(
+			[]tuple.Tuple{p, vertices[boundaryNeighbors[0]], vertices[boundaryNeighbors[1]]},
+			[]float64{3.0 / 4, 1.0 / 8, 1.0 / 8})
+	case len(boundaryNeighbors) == 0 && len(neighbors) >= 3:
+		n := len(neighbors)
+		beta := 3.0 / (8 * float64(n))
+		if n == 3 {
+			beta = 3.0 / 16
+		}
+		points := []tuple.Tuple{p}
+		weights := []float64{1 - float64(n)*beta}
+		for _, v := range neighbors {
+			points = append(points, vertices[v])
+			weights = append(weights, beta)
+		}
+		return weightedPoint(points, weights)
+	default:
+		return p
+	}
+}
+
+func weightedPoint(points []tuple.Tuple, weights []float64) tuple.Tuple {
+	var x, y, z float64
+	for i, p := range points {
+		x += p.X * weights[i]
+		y += p.Y * weights[i]
+		z += p.Z * weights[i]
+	}
+	return tuple.NewPoint(x, y, z)
+}
+
+// smoothMesh creates a mesh whose vertex normals are the average of the normals of the
+// triangles around each vertex, weighted by their area.
+func smoothMesh(vertices []tuple.Tuple, triangles [][3]int) *primitive.Mesh {
+	normals := make([]tuple.Tuple, len(vertices))
+	for i := range normals {
+		normals[i] = tuple.NewVector(0, 0, 0)
+	}
+	for _, t := range triangles {
+		p1, p2, p3 := vertices[t[0]], vertices[t[1]], vertices[t[2]]
+		// Matches the orientation of primitive.Triangle's normal.
+		faceNormal := p3.Sub(p1).Cross(p2.Sub(p1))
+		for _, v := range t {
+			normals[v] = normals[v].Add(faceNormal)
+		}
+	}
+	for i, n := range normals {
+		if n.Mag() > 0 {
+			normals[i] = n.Norm()
+		}
+	}
+
+	faces := make([]primitive.MeshFace, 0, len(triangles))
+	for _, t := range triangles {
+		face := primitive.MeshFace{Vertices: t, Normals: t, TextureCoords: [3]int{-1, -1, -1}}
+		if normals[t[0]].Mag() == 0 || normals[t[1]].Mag() == 0 || normals[t[2]].Mag() == 0 {
+			face.Normals = [3]int{-1, -1, -1}
+		}
+		faces = append(faces, face)
+	}
+	return primitive.NewMesh(vertices, normals, nil, faces)
+}
@@ -0,0 +1,144 @@
+package obj
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/primitive"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const tetrahedron = `
+v 1 1 1
+v 1 -1 -1
+v -1 1 -1
+v -1 -1 1
+f 1 2 3
+f 1 4 2
+f 1 3 4
+f 2 4 3
+`
+
+func TestParseRecordsFacesPerGroup(t *testing.T) {
+	file := `
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+f 1 2 3 4
+g Second
+f 3 2 1
+`
+
+	parsed, err := parse(strings.NewReader(file))
+
+	require.NoError(t, err)
+	require.Len(t, parsed.faces, 2)
+	assert.Equal(t, [][3]int{{0, 1, 2}, {0, 2, 3}}, faceTriangles(parsed.faces[0]))
+	assert.Equal(t, [][3]int{{2, 1, 0}}, faceTriangles(parsed.faces[1]))
+	assert.Same(t, parsed.rootGroup.Children()[0], parsed.faces[1].group)
+	// Meshes are left to Parse and ParseSubdivided.
+	assert.Len(t, parsed.rootGroup.Children(), 1)
+	assert.Empty(t, parsed.faces[1].group.Children())
+}
+
+func TestLoopSubdivisionOfClosedMesh(t *testing.T) {
+	parsed, err := parse(strings.NewReader(tetrahedron))
+	require.NoError(t, err)
+
+	vertices, triangles := loopSubdivide(parsed.vertices, faceTriangles(parsed.faces[0]))
+
+	require.Len(t, vertices, 10)
+	require.Len(t, triangles, 16)
+	// Each corner moves a quarter of the way towards the center, as do the new points on edges.
+	test.AssertAlmost(t, tuple.NewPoint(0.25, 0.25, 0.25), vertices[0])
+	test.AssertAlmost(t, tuple.NewPoint(-0.25, -0.25, 0.25), vertices[3])
+	test.AssertAlmost(t, tuple.NewPoint(0.5, 0, 0), vertices[4])
+	assert.Equal(t, [3]int{0, 4, 6}, triangles[0])
+	assert.Equal(t, [3]int{4, 5, 6}, triangles[3])
+}
+
+func TestLoopSubdivisionKeepsOpenMeshOnItsPlane(t *testing.T) {
+	file := `
+v 0 0 0
+v 1 0 0
+v 1 0 1
+v 0 0 1
+f 1 2 3 4
+`
+	parsed, err := parse(strings.NewReader(file))
+	require.NoError(t, err)
+
+	vertices, triangles := loopSubdivide(parsed.vertices, faceTriangles(parsed.faces[0]))
+
+	require.Len(t, vertices, 9)
+	require.Len(t, triangles, 8)
+	for _, v := range vertices {
+		assert.Equal(t, 0.0, v.Y)
+	}
+	test.AssertAlmost(t, tuple.NewPoint(0.5, 0, 0), vertices[4])
+	// The corner opposite the diagonal sits where its two boundary edges meet.
+	test.AssertAlmost(t, tuple.NewPoint(0.875, 0, 0.125), vertices[1])
+}
+
+func TestParseSubdividedCreatesSmoothMeshPerGroup(t *testing.T) {
+	file := tetrahedron + `
+g Second
+v 5 0 0
+v 6 0 0
+v 6 0 1
+f 5 6 7
+`
+
+	parsed, err := ParseSubdivided(strings.NewReader(file), 2)
+
+	require.NoError(t, err)
+	children := parsed.(*primitive.Group).Children()
+	require.Len(t, children, 2)
+	second := children[0].(*primitive.Group)
+	mesh := children[1].(*primitive.Mesh)
+	require.Equal(t, 64, mesh.FaceCount())
+	assert.Equal(t, 16, second.Children()[0].(*primitive.Mesh).FaceCount())
+
+	for i := 0; i < mesh.FaceCount(); i++ {
+		p1, p2, p3 := mesh.FaceVertices(i)
+		n1, _, _, ok := mesh.FaceNormals(i)
+		require.True(t, ok)
+		flat := primitive.NewTriangle(p1, p2, p3)
+		faceNormal := flat.NormalAt(p1, primitive.Intersection{})
+		assert.Greater(t, n1.Dot(faceNormal), 0.0)
+		test.AssertAlmost(t, 1.0, n1.Mag())
+	}
+}
+
+func TestParseSubdividedWithoutLevelsSmoothsNormals(t *testing.T) {
+	parsed, err := ParseSubdivided(strings.NewReader(tetrahedron), 0)
+
+	require.NoError(t, err)
+	children := parsed.(*primitive.Group).Children()
+	require.Len(t, children, 1)
+	mesh := children[0].(*primitive.Mesh)
+	require.Equal(t, 4, mesh.FaceCount())
+	p1, _, _ := mesh.FaceVertices(0)
+	n1, _, _, ok := mesh.FaceNormals(0)
+	require.True(t, ok)
+	assert.Equal(t, tuple.NewPoint(1, 1, 1), p1)
+	test.AssertAlmost(t, tuple.NewVector(1, 1, 1).Norm(), n1.Neg())
+}
+
+func TestParseSubdividedReportsErrors(t *testing.T) {
+	_, err := ParseSubdivided(strings.NewReader("v 1 2\n"), 1)
+
+	assert.Contains(t, err.Error(), "expected at least 3 arguments")
+}
+
+func faceTriangles(g groupFaces) [][3]int {
+	triangles := make([][3]int, len(g.faces))
+	for i, f := range g.faces {
+		triangles[i] = f.Vertices
+	}
+	return triangles
+}
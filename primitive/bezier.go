@@ -0,0 +1,122 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// bezierNormalDelta is how far inside the patch normals are sampled at points where the
+// surface's derivatives vanish, such as where a row of control points meets at a single point.
+const bezierNormalDelta = 1e-4
+
+// BezierPatch is a bicubic Bezier surface defined by a 4 by 4 grid of control points. It is
+// rendered by tessellating it into smooth triangles.
+type BezierPatch struct {
+	points [16]tuple.Tuple
+}
+
+// NewBezierPatch creates a patch from control points listed a row at a time, with u increasing
+// along each row and v increasing from one row to the next.
+func NewBezierPatch(points [16]tuple.Tuple) BezierPatch {
+	return BezierPatch{points}
+}
+
+func (b BezierPatch) ControlPoints() [16]tuple.Tuple {
+	return b.points
+}
+
+// PointAt returns the point on the surface at parameters u and v, each between 0 and 1.
+func (b BezierPatch) PointAt(u, v float64) tuple.Tuple {
+	bu, bv := bernstein(u), bernstein(v)
+	p := b.sum(func(i, j int) float64 { return bu[i] * bv[j] })
+	return tuple.NewPoint(p.X, p.Y, p.Z)
+}
+
+// NormalAt returns the unit normal of the surface at parameters u and v.
+func (b BezierPatch) NormalAt(u, v float64) tuple.Tuple {
+	n := b.tangentV(u, v).Cross(b.tangentU(u, v))
+	if n.Mag() > 0 {
+		return n.Norm()
+	}
+	// Move slightly towards the middle of the patch, where the derivatives are defined.
+	u += math.Copysign(bezierNormalDelta, 0.5-u)
+	v += math.Copysign(bezierNormalDelta, 0.5-v)
+	return b.tangentV(u, v).Cross(b.tangentU(u, v)).Norm()
+}
+
+func (b BezierPatch) tangentU(u, v float64) tuple.Tuple {
+	du, bv := bernsteinDerivative(u), bernstein(v)
+	return b.sum(func(i, j int) float64 { return du[i] * bv[j] })
+}
+
+func (b BezierPatch) tangentV(u, v float64) tuple.Tuple {
+	bu, dv := bernstein(u), bernsteinDerivative(v)
+	return b.sum(func(i, j int) float64 { return bu[i] * dv[j] })
+}
+
+// sum adds up the control points, as vectors, multiplied by weight(i, j), where i is the column
+// and j the row of each point.
+func (b BezierPatch) sum(weight func(i, j int) float64) tuple.Tuple {
+	var x, y, z float64
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 4; i++ {
+			w := weight(i, j)
+			p := b.points[j*4+i]
+			x += p.X * w
+			y += p.Y * w
+			z += p.Z * w
+		}
+	}
+	return tuple.New(x, y, z, 0)
+}
+
+// Tessellate approximates the patch with a group of smooth triangles, dividing it into level by
+// level quads, each split into two triangles. Triangles with no area, found where control points
+// coincide, are left out.
+func (b BezierPatch) Tessellate(level int) *Group {
+	if level < 1 {
+		panic("tessellation level must be at least 1")
+	}
+	points := make([]tuple.Tuple, 0, (level+1)*(level+1))
+	normals := make([]tuple.Tuple, 0, (level+1)*(level+1))
+	for j := 0; j <= level; j++ {
+		for i := 0; i <= level; i++ {
+			u, v := float64(i)/float64(level), float64(j)/float64(level)
+			points = append(points, b.PointAt(u, v))
+			normals = append(normals, b.NormalAt(u, v))
+		}
+	}
+
+	g := NewGroup()
+	index := func(i, j int) int { return j*(level+1) + i }
+	for j := 0; j < level; j++ {
+		for i := 0; i < level; i++ {
+			quad := [2][3]int{
+				{index(i, j), index(i+1, j), index(i+1, j+1)},
+				{index(i, j), index(i+1, j+1), index(i, j+1)},
+			}
+			for _, tri := range quad {
+				p1, p2, p3 := points[tri[0]], points[tri[1]], points[tri[2]]
+				if p2.Sub(p1).Cross(p3.Sub(p1)).Mag() == 0 {
+					continue
+				}
+				t := NewSmoothTriangle(p1, p2, p3, normals[tri[0]], normals[tri[1]], normals[tri[2]])
+				g.Add(&t)
+			}
+		}
+	}
+	return g
+}
+
+// bernstein returns the weights of the four cubic Bernstein polynomials at t.
+func bernstein(t float64) [4]float64 {
+	s := 1 - t
+	return [4]float64{s * s * s, 3 * t * s * s, 3 * t * t * s, t * t * t}
+}
+
+// bernsteinDerivative returns the derivatives of the four cubic Bernstein polynomials at t.
+func bernsteinDerivative(t float64) [4]float64 {
+	s := 1 - t
+	return [4]float64{-3 * s * s, 3*s*s - 6*t*s, 6*t*s - 3*t*t, 3 * t * t}
+}
@@ -0,0 +1,118 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// flatPatch is a patch on the x-z plane from 0 to 3 along each axis.
+func flatPatch() BezierPatch {
+	var points [16]tuple.Tuple
+	for j := 0; j < 4; j++ {
+		for i := 0; i < 4; i++ {
+			points[j*4+i] = tuple.NewPoint(float64(i), 0, float64(j))
+		}
+	}
+	return NewBezierPatch(points)
+}
+
+// domePatch raises the middle control points of flatPatch, and collapses the first row into a
+// single point.
+func domePatch() BezierPatch {
+	points := flatPatch().ControlPoints()
+	for _, i := range []int{5, 6, 9, 10} {
+		points[i].Y = 2
+	}
+	for i := 0; i < 4; i++ {
+		points[i] = tuple.NewPoint(1.5, 0, 0)
+	}
+	return NewBezierPatch(points)
+}
+
+func TestBezierPatchPassesThroughCorners(t *testing.T) {
+	b := domePatch()
+	points := b.ControlPoints()
+
+	test.AssertAlmost(t, points[0], b.PointAt(0, 0))
+	test.AssertAlmost(t, points[3], b.PointAt(1, 0))
+	test.AssertAlmost(t, points[12], b.PointAt(0, 1))
+	test.AssertAlmost(t, points[15], b.PointAt(1, 1))
+}
+
+func TestPointOnBezierPatch(t *testing.T) {
+	flat := flatPatch()
+	dome := domePatch()
+
+	test.AssertAlmost(t, tuple.NewPoint(0.75, 0, 2.25), flat.PointAt(0.25, 0.75))
+	test.AssertAlmost(t, tuple.NewPoint(1.5, 1.125, 1.5), dome.PointAt(0.5, 0.5))
+}
+
+func TestNormalOnBezierPatch(t *testing.T) {
+	flat := flatPatch()
+	dome := domePatch()
+
+	test.AssertAlmost(t, tuple.NewVector(0, 1, 0), flat.NormalAt(0.3, 0.6))
+	test.AssertAlmost(t, tuple.NewVector(0, 1, 0), dome.NormalAt(0.5, 0.5))
+	assert.Greater(t, dome.NormalAt(0.5, 1).Z, 0.0)
+}
+
+func TestNormalWhereBezierPatchIsDegenerate(t *testing.T) {
+	dome := domePatch()
+
+	n := dome.NormalAt(0.5, 0)
+
+	assert.False(t, math.IsNaN(n.X))
+	test.AssertAlmost(t, 1.0, n.Mag())
+	assert.Less(t, n.Z, 0.0)
+}
+
+func TestTessellatingBezierPatch(t *testing.T) {
+	b := flatPatch()
+
+	g := b.Tessellate(4)
+
+	require.Len(t, g.Children(), 32)
+	tri := g.Children()[0].(*SmoothTriangle)
+	p1, p2, p3 := tri.Vertices()
+	n1, _, _ := tri.Normals()
+	assert.Equal(t, b.PointAt(0, 0), p1)
+	assert.Equal(t, b.PointAt(0.25, 0), p2)
+	assert.Equal(t, b.PointAt(0.25, 0.25), p3)
+	assert.Equal(t, b.NormalAt(0, 0), n1)
+	assert.Equal(t, tuple.NewPoint(0, 0, 0), g.Bounds().Min())
+	assert.Equal(t, tuple.NewPoint(3, 0, 3), g.Bounds().Max())
+}
+
+func TestTessellationSkipsDegenerateTriangles(t *testing.T) {
+	b := domePatch()
+
+	g := b.Tessellate(4)
+
+	assert.Len(t, g.Children(), 28)
+}
+
+func TestTessellatedPatchIsHitNearSurface(t *testing.T) {
+	points := flatPatch().ControlPoints()
+	for _, i := range []int{5, 6, 9, 10} {
+		points[i].Y = 2
+	}
+	b := NewBezierPatch(points)
+	g := b.Tessellate(16)
+	r := ray.New(tuple.NewPoint(1.52, 5, 1.47), tuple.NewVector(0, -1, 0))
+	u, v := 1.52/3, 1.47/3
+
+	xs := g.Intersects(r)
+
+	require.Len(t, xs, 1)
+	assert.InDelta(t, 5-b.PointAt(u, v).Y, xs[0].Distance(), 1e-2)
+}
+
+func TestTessellatingBezierPatchPanicsWithInvalidLevel(t *testing.T) {
+	assert.Panics(t, func() { flatPatch().Tessellate(0) })
+}
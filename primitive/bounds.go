@@ -63,11 +63,25 @@ func (b *BoundingBox) ContainsPoint(point tuple.Tuple) bool {
 		b.min.Z <= point.Z && point.Z <= b.max.Z
 }
 
+// containsPointWithin reports whether point is inside the box or within tolerance of it.
+func (b *BoundingBox) containsPointWithin(point tuple.Tuple, tolerance float64) bool {
+	return b.min.X-tolerance <= point.X && point.X <= b.max.X+tolerance &&
+		b.min.Y-tolerance <= point.Y && point.Y <= b.max.Y+tolerance &&
+		b.min.Z-tolerance <= point.Z && point.Z <= b.max.Z+tolerance
+}
+
 func (b *BoundingBox) ContainsBox(b2 *BoundingBox) bool {
 	return b.ContainsPoint(b2.min) && b.ContainsPoint(b2.max)
 }
 
 func (b *BoundingBox) intersects(ray ray.Ray) bool {
+	_, _, ok := b.intersectionRange(ray)
+	return ok
+}
+
+// intersectionRange returns the distances along ray at which it enters and leaves the box. The
+// entry may be behind the ray's origin if the origin is inside the box.
+func (b *BoundingBox) intersectionRange(ray ray.Ray) (tmin, tmax float64, ok bool) {
 	origX, origY, origZ, _ := ray.Origin().XYZW()
 	invDirX, invDirY, invDirZ, _ := ray.InvDirection().XYZW()
 
@@ -77,10 +91,10 @@ func (b *BoundingBox) intersects(ray ray.Ray) bool {
 	t4 := (b.max.Y - origY) * invDirY
 	t5 := (b.min.Z - origZ) * invDirZ
 	t6 := (b.max.Z - origZ) * invDirZ
-	tmin := math.Max(math.Max(math.Min(t1, t2), math.Min(t3, t4)), math.Min(t5, t6))
-	tmax := math.Min(math.Min(math.Max(t1, t2), math.Max(t3, t4)), math.Max(t5, t6))
+	tmin = math.Max(math.Max(math.Min(t1, t2), math.Min(t3, t4)), math.Min(t5, t6))
+	tmax = math.Min(math.Min(math.Max(t1, t2), math.Max(t3, t4)), math.Max(t5, t6))
 
-	return tmax >= math.Max(tmin, 0)
+	return tmin, tmax, tmax >= math.Max(tmin, 0)
 }
 
 func (b *BoundingBox) Transform(m matrix.Matrix) *BoundingBox {
@@ -31,6 +31,10 @@ func (co *Cone) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
 	return co.worldNormalAt(worldPoint, xn, co)
 }
 
+func (co *Cone) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return co.worldUVAt(worldPoint, co)
+}
+
 func (co *Cone) localIntersects(localRay ray.Ray) Intersections {
 	direction := localRay.Direction()
 	a := direction.X*direction.X - direction.Y*direction.Y + direction.Z*direction.Z
@@ -112,6 +116,19 @@ func (co *Cone) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple.Tupl
 	return tuple.NewVector(localPoint.X, y, localPoint.Z)
 }
 
+func (co *Cone) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	dist := localPoint.X*localPoint.X + localPoint.Z*localPoint.Z
+	y := localPoint.Y
+	if dist <= y*y+float.Epsilon && (y >= co.maxY-float.Epsilon || y <= co.minY+float.Epsilon) {
+		// The cap at y has radius |y|, so it's scaled to fill the texture as a unit disc would.
+		if r := math.Abs(y); r > float.Epsilon {
+			return discUV(tuple.NewPoint(localPoint.X/r, y, localPoint.Z/r))
+		}
+		return discUV(localPoint)
+	}
+	return cylindricalUV(localPoint)
+}
+
 func (c *Cone) Bounds() *BoundingBox {
 	a := math.Abs(c.minY)
 	b := math.Abs(c.maxY)
@@ -22,6 +22,10 @@ func (c *Cube) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
 	return c.worldNormalAt(worldPoint, xn, c)
 }
 
+func (c *Cube) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return c.worldUVAt(worldPoint, c)
+}
+
 func (c *Cube) localIntersects(localRay ray.Ray) Intersections {
 	xtmin, xtmax := c.checkAxis(localRay.Origin().X, localRay.Direction().X)
 	ytmin, ytmax := c.checkAxis(localRay.Origin().Y, localRay.Direction().Y)
@@ -51,6 +55,27 @@ func (c *Cube) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple.Tuple
 	return tuple.NewVector(0, 0, localPoint.Z)
 }
 
+// localUVAt maps each face of the cube to the full range of texture coordinates, oriented as in
+// the Ray Tracer Challenge's cube mapping.
+func (c *Cube) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	x, y, z, _ := localPoint.XYZW()
+	absx, absy, absz := math.Abs(x), math.Abs(y), math.Abs(z)
+	maxc := max(absx, absy, absz)
+	switch {
+	case maxc == absx && x > 0:
+		return (1 - z) / 2, (y + 1) / 2
+	case maxc == absx:
+		return (z + 1) / 2, (y + 1) / 2
+	case maxc == absy && y > 0:
+		return (x + 1) / 2, (1 - z) / 2
+	case maxc == absy:
+		return (x + 1) / 2, (z + 1) / 2
+	case z > 0:
+		return (x + 1) / 2, (y + 1) / 2
+	}
+	return (1 - x) / 2, (y + 1) / 2
+}
+
 func (c *Cube) checkAxis(origin, direction float64) (float64, float64) {
 	invDirection := 1 / direction
 	tmin := (-1 - origin) * invDirection
@@ -31,6 +31,10 @@ func (cyl *Cylinder) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tup
 	return cyl.worldNormalAt(worldPoint, xn, cyl)
 }
 
+func (cyl *Cylinder) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return cyl.worldUVAt(worldPoint, cyl)
+}
+
 func (cyl *Cylinder) localIntersects(localRay ray.Ray) Intersections {
 	direction := localRay.Direction()
 	a := direction.X*direction.X + direction.Z*direction.Z
@@ -105,6 +109,14 @@ func (cyl *Cylinder) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple
 	return tuple.NewVector(localPoint.X, 0, localPoint.Z)
 }
 
+func (cyl *Cylinder) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	dist := localPoint.X*localPoint.X + localPoint.Z*localPoint.Z
+	if dist < 1 && (localPoint.Y >= cyl.maxY-float.Epsilon || localPoint.Y <= cyl.minY+float.Epsilon) {
+		return discUV(localPoint)
+	}
+	return cylindricalUV(localPoint)
+}
+
 func (cyl *Cylinder) Bounds() *BoundingBox {
 	b := NewBoundingBox(
 		tuple.NewPoint(-1, cyl.minY, -1),
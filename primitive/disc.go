@@ -0,0 +1,79 @@
+package primitive
+
+import (
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Disc is a flat circle of radius 1 on the x-z plane centered on the origin, optionally with a
+// hole in the middle to form an annulus.
+type Disc struct {
+	innerRadius float64
+	singleSided bool
+	data
+}
+
+func NewDisc() Disc {
+	return Disc{0, false, newData()}
+}
+
+// NewAnnulus creates a Disc with a hole of innerRadius in the middle, which must be at least 0
+// and less than 1.
+func NewAnnulus(innerRadius float64) Disc {
+	if innerRadius < 0 || innerRadius >= 1 {
+		panic("annulus inner radius must be at least 0 and less than 1")
+	}
+	return Disc{innerRadius, false, newData()}
+}
+
+func (d *Disc) InnerRadius() float64 {
+	return d.innerRadius
+}
+
+// SetSingleSided controls whether the disc can only be seen from above, where its normal points.
+// Rays approaching from below pass straight through a single-sided disc.
+func (d *Disc) SetSingleSided(singleSided bool) {
+	d.singleSided = singleSided
+}
+
+func (d *Disc) SingleSided() bool {
+	return d.singleSided
+}
+
+func (d *Disc) Intersects(worldRay ray.Ray) Intersections {
+	return d.worldIntersects(worldRay, d)
+}
+
+func (d *Disc) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
+	return d.worldNormalAt(worldPoint, xn, d)
+}
+
+func (d *Disc) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return d.worldUVAt(worldPoint, d)
+}
+
+func (d *Disc) localIntersects(localRay ray.Ray) Intersections {
+	distance, point, ok := xzPlaneIntersection(localRay, d.singleSided)
+	if !ok {
+		return nil
+	}
+	r2 := point.X*point.X + point.Z*point.Z
+	if r2 > 1 || r2 < d.innerRadius*d.innerRadius {
+		return nil
+	}
+	return NewIntersections(NewIntersection(distance, d))
+}
+
+func (d *Disc) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple.Tuple {
+	return tuple.NewVector(0, 1, 0)
+}
+
+func (d *Disc) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	return discUV(localPoint)
+}
+
+var discBounds = NewBoundingBox(tuple.NewPoint(-1, 0, -1), tuple.NewPoint(1, 0, 1))
+
+func (d *Disc) Bounds() *BoundingBox {
+	return discBounds
+}
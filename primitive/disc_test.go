@@ -0,0 +1,121 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRayIntersectingDisc(t *testing.T) {
+	d := NewDisc()
+
+	examples := []struct {
+		origin, direction tuple.Tuple
+		count             int
+	}{
+		{tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0), 1},
+		{tuple.NewPoint(0.5, -1, 0.5), tuple.NewVector(0, 1, 0), 1},
+		{tuple.NewPoint(0.8, 1, -0.5), tuple.NewVector(0, -1, 0), 1},
+		{tuple.NewPoint(0.8, 1, -0.8), tuple.NewVector(0, -1, 0), 0},
+		{tuple.NewPoint(0, 1, -5), tuple.NewVector(0, 0, 1), 0},
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1), 0},
+	}
+
+	for _, e := range examples {
+		r := ray.New(e.origin, e.direction)
+		xs := d.localIntersects(r)
+		assert.Len(t, xs, e.count)
+	}
+}
+
+func TestRayIntersectingDiscGivesDistance(t *testing.T) {
+	d := NewDisc()
+	r := ray.New(tuple.NewPoint(0, 2, -2), tuple.NewVector(0, -1, 1))
+
+	xs := d.localIntersects(r)
+
+	require.Len(t, xs, 1)
+	assert.Equal(t, 2.0, xs[0].distance)
+	assert.Equal(t, &d, xs[0].object)
+}
+
+func TestRayIntersectingAnnulus(t *testing.T) {
+	d := NewAnnulus(0.5)
+
+	examples := []struct {
+		origin tuple.Tuple
+		count  int
+	}{
+		{tuple.NewPoint(0, 1, 0), 0},
+		{tuple.NewPoint(0.3, 1, -0.3), 0},
+		{tuple.NewPoint(0, 1, 0.5), 1},
+		{tuple.NewPoint(-0.75, 1, 0), 1},
+		{tuple.NewPoint(0, 1, 1.1), 0},
+	}
+
+	for _, e := range examples {
+		r := ray.New(e.origin, tuple.NewVector(0, -1, 0))
+		xs := d.localIntersects(r)
+		assert.Len(t, xs, e.count)
+	}
+}
+
+func TestSingleSidedDiscIsOnlyHitFromAbove(t *testing.T) {
+	d := NewDisc()
+	d.SetSingleSided(true)
+
+	above := d.localIntersects(ray.New(tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0)))
+	below := d.localIntersects(ray.New(tuple.NewPoint(0, -1, 0), tuple.NewVector(0, 1, 0)))
+
+	assert.True(t, d.SingleSided())
+	assert.Len(t, above, 1)
+	assert.Empty(t, below)
+}
+
+func TestSingleSidedTransformedDisc(t *testing.T) {
+	d := NewDisc()
+	d.SetSingleSided(true)
+	d.SetTransform(transform.RotationX(-math.Pi / 2))
+
+	front := d.Intersects(ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1)))
+	back := d.Intersects(ray.New(tuple.NewPoint(0, 0, 5), tuple.NewVector(0, 0, -1)))
+
+	assert.Len(t, front, 1)
+	assert.Empty(t, back)
+}
+
+func TestNormalOfDisc(t *testing.T) {
+	d := NewAnnulus(0.25)
+
+	n := d.localNormalAt(tuple.NewPoint(0.5, 0, 0), Intersection{})
+
+	assert.Equal(t, tuple.NewVector(0, 1, 0), n)
+}
+
+func TestDiscUV(t *testing.T) {
+	d := NewDisc()
+
+	assertUV(t, &d, tuple.NewPoint(0, 0, 0), 0.5, 0.5)
+	assertUV(t, &d, tuple.NewPoint(1, 0, 0), 1, 0.5)
+	assertUV(t, &d, tuple.NewPoint(0, 0, 1), 0.5, 0)
+	assertUV(t, &d, tuple.NewPoint(-0.5, 0, -0.5), 0.25, 0.75)
+}
+
+func TestDiscBounds(t *testing.T) {
+	d := NewAnnulus(0.5)
+
+	b := d.Bounds()
+
+	assert.Equal(t, tuple.NewPoint(-1, 0, -1), b.min)
+	assert.Equal(t, tuple.NewPoint(1, 0, 1), b.max)
+}
+
+func TestAnnulusPanicsWithInvalidInnerRadius(t *testing.T) {
+	assert.Panics(t, func() { NewAnnulus(-0.1) })
+	assert.Panics(t, func() { NewAnnulus(1) })
+}
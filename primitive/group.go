@@ -29,11 +29,23 @@ func (g *Group) Add(p ...Primitive) {
 			panic("can't add group to itself")
 		}
 		p.setParent(g)
-		g.bounds.AddBox(p.Bounds().Transform(p.Transform()))
+		g.bounds.AddBox(parentBounds(p))
 	}
 	g.children = append(g.children, p...)
 }
 
+// UpdateBounds recomputes the bounds of the group and the groups within it, which is needed
+// after moving primitives that were already added.
+func (g *Group) UpdateBounds() {
+	g.bounds = *NewEmptyBoundingBox()
+	for _, c := range g.children {
+		if u, ok := c.(interface{ UpdateBounds() }); ok {
+			u.UpdateBounds()
+		}
+		g.bounds.AddBox(parentBounds(c))
+	}
+}
+
 func (g *Group) Children() []Primitive {
 	return g.children
 }
@@ -65,6 +77,10 @@ func (g *Group) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple.Tupl
 	panic("can't compute local normal on a group")
 }
 
+func (g *Group) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	panic("can't compute texture coordinates on a group")
+}
+
 func (g *Group) Bounds() *BoundingBox {
 	return &g.bounds
 }
@@ -115,3 +115,22 @@ func TestMaterialOfChildIsMaterialOfGroup(t *testing.T) {
 
 	assert.Equal(t, pattern, s.Material().Pattern())
 }
+
+func TestUpdateBoundsAfterMovingChildren(t *testing.T) {
+	inner := NewGroup()
+	s := NewSphere()
+	inner.Add(&s)
+	shared := NewGroup()
+	c := NewCube()
+	shared.Add(&c)
+	g := NewGroup()
+	g.Add(inner, NewInstance(shared))
+
+	s.SetTransform(transform.Translation(5, 0, 0))
+	c.SetTransform(transform.Translation(0, -5, 0))
+	g.UpdateBounds()
+
+	assert.Equal(t, tuple.NewPoint(4, -1, -1), inner.Bounds().Min())
+	assert.Equal(t, tuple.NewPoint(-1, -6, -1), g.Bounds().Min())
+	assert.Equal(t, tuple.NewPoint(6, 1, 1), g.Bounds().Max())
+}
@@ -0,0 +1,237 @@
+package primitive
+
+import (
+	"image"
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/float"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Heightfield is a surface defined by a grid of heights, such as terrain. The grid spans 0 to 1
+// along the x and z axes, with the heights along the y axis. Each cell of the grid is drawn as
+// two triangles, but they are found as needed while intersecting rather than stored.
+type Heightfield struct {
+	width, depth int
+	heights      []float64
+	bounds       *BoundingBox
+	data
+}
+
+// NewHeightfield creates a Heightfield from width by depth heights, listed a row at a time with
+// x increasing along each row and z increasing from one row to the next. There must be at least
+// two samples in each direction.
+func NewHeightfield(width, depth int, heights []float64) Heightfield {
+	if width < 2 || depth < 2 {
+		panic("heightfield needs at least 2 samples in each direction")
+	}
+	if len(heights) != width*depth {
+		panic("heightfield needs width*depth heights")
+	}
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, h := range heights {
+		minY = math.Min(minY, h)
+		maxY = math.Max(maxY, h)
+	}
+	bounds := NewBoundingBox(tuple.NewPoint(0, minY, 0), tuple.NewPoint(1, maxY, 1))
+	return Heightfield{width, depth, heights, bounds, newData()}
+}
+
+// NewHeightfieldFromFunc creates a Heightfield of width by depth samples of height, which is
+// given x and z coordinates between 0 and 1.
+func NewHeightfieldFromFunc(width, depth int, height func(x, z float64) float64) Heightfield {
+	heights := make([]float64, 0, width*depth)
+	for j := 0; j < depth; j++ {
+		for i := 0; i < width; i++ {
+			heights = append(heights, height(
+				float64(i)/float64(width-1),
+				float64(j)/float64(depth-1)))
+		}
+	}
+	return NewHeightfield(width, depth, heights)
+}
+
+// NewHeightfieldFromImage creates a Heightfield with a sample for each pixel of img, whose
+// brightness from 0 to 1 gives the height. The top row of the image is at z = 0, so that an
+// ImagePattern of the same image lines up with the surface.
+func NewHeightfieldFromImage(img image.Image) Heightfield {
+	b := img.Bounds()
+	heights := make([]float64, 0, b.Dx()*b.Dy())
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			r, g, bl := floatcolor.Float64Model.Convert(img.At(x, y)).(floatcolor.Float64Color).RGB()
+			heights = append(heights, (r+g+bl)/3)
+		}
+	}
+	return NewHeightfield(b.Dx(), b.Dy(), heights)
+}
+
+// Size returns the number of samples along the x and z axes.
+func (h *Heightfield) Size() (width, depth int) {
+	return h.width, h.depth
+}
+
+func (h *Heightfield) Intersects(worldRay ray.Ray) Intersections {
+	return h.worldIntersects(worldRay, h)
+}
+
+func (h *Heightfield) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
+	return h.worldNormalAt(worldPoint, xn, h)
+}
+
+func (h *Heightfield) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return h.worldUVAt(worldPoint, h)
+}
+
+// localIntersects walks the ray through the cells of the grid it passes over, in order, testing
+// only the triangles of those cells.
+func (h *Heightfield) localIntersects(localRay ray.Ray) Intersections {
+	start, end, ok := h.bounds.intersectionRange(localRay)
+	if !ok {
+		return nil
+	}
+
+	// Work in grid units, where each cell is one unit square.
+	cellsX, cellsZ := float64(h.width-1), float64(h.depth-1)
+	origin, direction := localRay.Origin(), localRay.Direction()
+	gx, gz := origin.X*cellsX, origin.Z*cellsZ
+	dx, dz := direction.X*cellsX, direction.Z*cellsZ
+
+	i := clampCell(gx+dx*start, h.width-1)
+	j := clampCell(gz+dz*start, h.depth-1)
+	stepI, nextX, deltaX := gridStep(gx, dx, i)
+	stepJ, nextZ, deltaZ := gridStep(gz, dz, j)
+
+	var xs Intersections
+	t := start
+	for t <= end && i >= 0 && i < h.width-1 && j >= 0 && j < h.depth-1 {
+		exit := math.Min(math.Min(nextX, nextZ), end)
+		for _, x := range h.cellIntersects(localRay, i, j, t, exit) {
+			// A ray through an edge shared by two triangles hits both.
+			if len(xs) == 0 || !float.Equal(xs[len(xs)-1].distance, x.distance) {
+				xs = append(xs, x)
+			}
+		}
+		if nextX < nextZ {
+			i += stepI
+			t, nextX = nextX, nextX+deltaX
+		} else {
+			j += stepJ
+			t, nextZ = nextZ, nextZ+deltaZ
+		}
+	}
+	return xs
+}
+
+// clampCell returns the cell containing grid coordinate g, keeping points on the edge of the
+// grid in its outermost cells.
+func clampCell(g float64, cells int) int {
+	c := int(math.Floor(g))
+	if c < 0 {
+		return 0
+	}
+	if c >= cells {
+		return cells - 1
+	}
+	return c
+}
+
+// gridStep returns the direction to move between cells along one axis, the distance along the
+// ray at which it leaves cell, and the distance it takes to cross a whole cell.
+func gridStep(origin, direction float64, cell int) (step int, next, delta float64) {
+	switch {
+	case direction > 0:
+		return 1, (float64(cell+1) - origin) / direction, 1 / direction
+	case direction < 0:
+		return -1, (float64(cell) - origin) / direction, -1 / direction
+	default:
+		return 0, math.Inf(1), math.Inf(1)
+	}
+}
+
+// cellIntersects tests the triangles of cell (i, j) for hits between distances enter and exit.
+func (h *Heightfield) cellIntersects(localRay ray.Ray, i, j int, enter, exit float64) Intersections {
+	h00, h10 := h.height(i, j), h.height(i+1, j)
+	h01, h11 := h.height(i, j+1), h.height(i+1, j+1)
+
+	// Skip cells the ray passes entirely above or below.
+	y0 := localRay.Position(enter).Y
+	y1 := localRay.Position(exit).Y
+	if math.Min(y0, y1) > math.Max(math.Max(h00, h10), math.Max(h01, h11)) ||
+		math.Max(y0, y1) < math.Min(math.Min(h00, h10), math.Min(h01, h11)) {
+		return nil
+	}
+
+	p00, p10 := h.vertex(i, j, h00), h.vertex(i+1, j, h10)
+	p01, p11 := h.vertex(i, j+1, h01), h.vertex(i+1, j+1, h11)
+	var xs Intersections
+	for _, tri := range [2][3]tuple.Tuple{{p00, p11, p10}, {p00, p01, p11}} {
+		d, _, _, ok := triangleHit(localRay, tri[0], tri[1].Sub(tri[0]), tri[2].Sub(tri[0]))
+		if ok && d >= enter && d <= exit {
+			xs = append(xs, NewIntersection(d, h))
+		}
+	}
+	if len(xs) == 2 && xs[1].distance < xs[0].distance {
+		xs[0], xs[1] = xs[1], xs[0]
+	}
+	return xs
+}
+
+func (h *Heightfield) height(i, j int) float64 {
+	return h.heights[j*h.width+i]
+}
+
+func (h *Heightfield) vertex(i, j int, height float64) tuple.Tuple {
+	return tuple.NewPoint(float64(i)/float64(h.width-1), height, float64(j)/float64(h.depth-1))
+}
+
+// localNormalAt blends the normals at the corners of the cell containing localPoint, giving a
+// smooth surface across cells.
+func (h *Heightfield) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple.Tuple {
+	gx := localPoint.X * float64(h.width-1)
+	gz := localPoint.Z * float64(h.depth-1)
+	i := clampCell(gx, h.width-1)
+	j := clampCell(gz, h.depth-1)
+	fx := math.Max(0, math.Min(1, gx-float64(i)))
+	fz := math.Max(0, math.Min(1, gz-float64(j)))
+
+	n := h.vertexNormal(i, j).Mul((1 - fx) * (1 - fz)).
+		Add(h.vertexNormal(i+1, j).Mul(fx * (1 - fz))).
+		Add(h.vertexNormal(i, j+1).Mul((1 - fx) * fz)).
+		Add(h.vertexNormal(i+1, j+1).Mul(fx * fz))
+	return n.Norm()
+}
+
+// vertexNormal estimates the normal at a sample from the slope of the heights around it.
+func (h *Heightfield) vertexNormal(i, j int) tuple.Tuple {
+	i0, i1 := maxInt(i-1, 0), minInt(i+1, h.width-1)
+	j0, j1 := maxInt(j-1, 0), minInt(j+1, h.depth-1)
+	slopeX := (h.height(i1, j) - h.height(i0, j)) * float64(h.width-1) / float64(i1-i0)
+	slopeZ := (h.height(i, j1) - h.height(i, j0)) * float64(h.depth-1) / float64(j1-j0)
+	return tuple.NewVector(-slopeX, 1, -slopeZ).Norm()
+}
+
+// localUVAt maps the grid onto the whole texture, with u increasing along x and v along -z.
+func (h *Heightfield) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	return localPoint.X, 1 - localPoint.Z
+}
+
+func (h *Heightfield) Bounds() *BoundingBox {
+	return h.bounds
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,190 @@
+package primitive
+
+import (
+	"image"
+	"image/color"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRayIntersectingFlatHeightfield(t *testing.T) {
+	h := NewHeightfield(2, 2, []float64{0.5, 0.5, 0.5, 0.5})
+
+	examples := []struct {
+		origin, direction tuple.Tuple
+		ts                []float64
+	}{
+		{tuple.NewPoint(0.3, 5, 0.7), tuple.NewVector(0, -1, 0), []float64{4.5}},
+		{tuple.NewPoint(0.99, 5, 0.01), tuple.NewVector(0, -1, 0), []float64{4.5}},
+		{tuple.NewPoint(0.5, -1, -1), tuple.NewVector(0, 1, 1), []float64{1.5}},
+		{tuple.NewPoint(1.1, 5, 0.5), tuple.NewVector(0, -1, 0), []float64{}},
+		{tuple.NewPoint(-1, 1, 0.5), tuple.NewVector(1, 0, 0), []float64{}},
+	}
+
+	for _, e := range examples {
+		r := ray.New(e.origin, e.direction)
+		xs := h.localIntersects(r)
+		require.Len(t, xs, len(e.ts))
+		for i := range e.ts {
+			test.AssertAlmost(t, e.ts[i], xs[i].distance)
+		}
+	}
+}
+
+func TestRayIntersectingHeightfieldPeak(t *testing.T) {
+	h := NewHeightfield(3, 3, []float64{
+		0, 0, 0,
+		0, 1, 0,
+		0, 0, 0,
+	})
+	r := ray.New(tuple.NewPoint(-1, 0.25, 0.5), tuple.NewVector(1, 0, 0))
+
+	xs := h.localIntersects(r)
+
+	require.Len(t, xs, 2)
+	test.AssertAlmost(t, 1.125, xs[0].distance)
+	test.AssertAlmost(t, 1.875, xs[1].distance)
+	assert.Equal(t, &h, xs[0].object)
+}
+
+func TestHeightfieldMatchesItsTriangles(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	h := NewHeightfieldFromFunc(9, 7, func(x, z float64) float64 { return rnd.Float64() })
+
+	var triangles []*Triangle
+	for j := 0; j < 6; j++ {
+		for i := 0; i < 8; i++ {
+			p00, p10 := h.vertex(i, j, h.height(i, j)), h.vertex(i+1, j, h.height(i+1, j))
+			p01, p11 := h.vertex(i, j+1, h.height(i, j+1)), h.vertex(i+1, j+1, h.height(i+1, j+1))
+			t1, t2 := NewTriangle(p00, p11, p10), NewTriangle(p00, p01, p11)
+			triangles = append(triangles, &t1, &t2)
+		}
+	}
+
+	for n := 0; n < 200; n++ {
+		origin := tuple.NewPoint(rnd.Float64()*3-1, rnd.Float64()*3-1, rnd.Float64()*3-1)
+		target := tuple.NewPoint(rnd.Float64(), rnd.Float64(), rnd.Float64())
+		r := ray.New(origin, target.Sub(origin))
+
+		var expected []float64
+		for _, tri := range triangles {
+			for _, x := range tri.localIntersects(r) {
+				expected = append(expected, x.distance)
+			}
+		}
+		xs := h.localIntersects(r)
+
+		require.Len(t, xs, len(expected), "ray %v", r)
+		for i := 1; i < len(xs); i++ {
+			assert.LessOrEqual(t, xs[i-1].distance, xs[i].distance)
+		}
+		for _, d := range expected {
+			found := false
+			for _, x := range xs {
+				found = found || math.Abs(x.distance-d) < 1e-9
+			}
+			assert.True(t, found, "missing intersection at %v for ray %v", d, r)
+		}
+	}
+}
+
+func TestRayIntersectingDenseHeightfield(t *testing.T) {
+	h := NewHeightfieldFromFunc(2048, 2048, func(x, z float64) float64 { return 0.5 })
+	r := ray.New(tuple.NewPoint(0, 1, 0), tuple.NewVector(1, -1, 1))
+
+	xs := h.localIntersects(r)
+
+	require.Len(t, xs, 1)
+	test.AssertAlmost(t, 0.5, xs[0].distance)
+}
+
+func TestIntersectingTransformedHeightfield(t *testing.T) {
+	h := NewHeightfieldFromFunc(5, 5, func(x, z float64) float64 { return x })
+	h.SetTransform(transform.Scaling(10, 2, 10))
+	r := ray.New(tuple.NewPoint(2.5, 10, 5), tuple.NewVector(0, -1, 0))
+
+	xs := h.Intersects(r)
+
+	require.Len(t, xs, 1)
+	test.AssertAlmost(t, 9.5, xs[0].distance)
+}
+
+func TestNormalOnHeightfield(t *testing.T) {
+	flat := NewHeightfield(2, 2, []float64{1, 1, 1, 1})
+	ramp := NewHeightfieldFromFunc(5, 5, func(x, z float64) float64 { return x })
+	r := math.Sqrt2 / 2
+
+	test.AssertAlmost(t, tuple.NewVector(0, 1, 0), flat.localNormalAt(tuple.NewPoint(0.2, 1, 0.8), Intersection{}))
+	test.AssertAlmost(t, tuple.NewVector(-r, r, 0), ramp.localNormalAt(tuple.NewPoint(0.3, 0.3, 0.6), Intersection{}))
+	test.AssertAlmost(t, tuple.NewVector(-r, r, 0), ramp.localNormalAt(tuple.NewPoint(1, 1, 1), Intersection{}))
+}
+
+func TestNormalOnHeightfieldIsInterpolated(t *testing.T) {
+	h := NewHeightfield(3, 2, []float64{
+		0, 0, 1,
+		0, 0, 1,
+	})
+
+	left := h.localNormalAt(tuple.NewPoint(0, 0, 0.5), Intersection{})
+	middle := h.localNormalAt(tuple.NewPoint(0.5, 0, 0.5), Intersection{})
+	between := h.localNormalAt(tuple.NewPoint(0.25, 0, 0.5), Intersection{})
+
+	test.AssertAlmost(t, tuple.NewVector(0, 1, 0), left)
+	assert.Less(t, middle.X, 0.0)
+	assert.Less(t, middle.X, between.X)
+	assert.Less(t, between.X, 0.0)
+}
+
+func TestHeightfieldUV(t *testing.T) {
+	h := NewHeightfield(2, 2, []float64{0, 0, 0, 0})
+
+	assertUV(t, &h, tuple.NewPoint(0, 0, 0), 0, 1)
+	assertUV(t, &h, tuple.NewPoint(1, 0, 1), 1, 0)
+	assertUV(t, &h, tuple.NewPoint(0.25, 0, 0.75), 0.25, 0.25)
+}
+
+func TestHeightfieldBounds(t *testing.T) {
+	h := NewHeightfield(2, 3, []float64{0.5, -1, 2, 0, 1, 1})
+
+	b := h.Bounds()
+
+	assert.Equal(t, tuple.NewPoint(0, -1, 0), b.min)
+	assert.Equal(t, tuple.NewPoint(1, 2, 1), b.max)
+}
+
+func TestHeightfieldFromFunc(t *testing.T) {
+	h := NewHeightfieldFromFunc(3, 2, func(x, z float64) float64 { return x + 10*z })
+
+	w, d := h.Size()
+
+	assert.Equal(t, 3, w)
+	assert.Equal(t, 2, d)
+	assert.Equal(t, []float64{0, 0.5, 1, 10, 10.5, 11}, h.heights)
+}
+
+func TestHeightfieldFromImage(t *testing.T) {
+	img := image.NewGray(image.Rect(0, 0, 2, 2))
+	img.SetGray(0, 0, color.Gray{255})
+	img.SetGray(1, 1, color.Gray{51})
+
+	h := NewHeightfieldFromImage(img)
+
+	require.Len(t, h.heights, 4)
+	test.AssertAlmost(t, 1.0, h.height(0, 0))
+	test.AssertAlmost(t, 0.0, h.height(1, 0))
+	test.AssertAlmost(t, 0.0, h.height(0, 1))
+	test.AssertAlmost(t, 0.2, h.height(1, 1))
+}
+
+func TestHeightfieldPanicsWithInvalidGrid(t *testing.T) {
+	assert.Panics(t, func() { NewHeightfield(1, 2, []float64{0, 0}) })
+	assert.Panics(t, func() { NewHeightfield(2, 2, []float64{0, 0, 0}) })
+}
@@ -0,0 +1,172 @@
+package primitive
+
+import (
+	"sync"
+
+	"github.com/danieltmartin/ray-tracer/material"
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Instance places a copy of shared geometry, such as a Group or Mesh, with its own transform
+// and optionally its own material, without duplicating the geometry. Any number of instances
+// may refer to the same geometry, which must not itself be added to a group.
+type Instance struct {
+	shared Primitive
+	// hits holds an instanceHit for each primitive of the shared geometry that has been hit,
+	// so that the same primitive of the same instance is always the same object.
+	hits sync.Map
+	data
+}
+
+// NewInstance creates an Instance of shared. SetMaterial overrides the material of every
+// primitive in it. Otherwise primitives of the shared geometry keep any material set on them or
+// on a group within it, and the rest take the material of the instance's parent group, as if
+// the shared geometry were a child of the instance.
+func NewInstance(shared Primitive) *Instance {
+	if shared.Parent() != nil {
+		panic("can't instance a primitive that is in a group")
+	}
+	return &Instance{shared: shared, data: newData()}
+}
+
+// Shared returns the geometry the instance refers to.
+func (i *Instance) Shared() Primitive {
+	return i.shared
+}
+
+func (i *Instance) Intersects(worldRay ray.Ray) Intersections {
+	return i.worldIntersects(worldRay, i)
+}
+
+func (i *Instance) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
+	panic("can't compute normal on an instance")
+}
+
+func (i *Instance) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	panic("can't compute texture coordinates on an instance")
+}
+
+func (i *Instance) localIntersects(localRay ray.Ray) Intersections {
+	xs := i.shared.Intersects(localRay)
+	for n := range xs {
+		xs[n].object = i.hit(xs[n].object)
+	}
+	return xs
+}
+
+func (i *Instance) hit(object Primitive) *instanceHit {
+	if h, ok := i.hits.Load(object); ok {
+		return h.(*instanceHit)
+	}
+	h, _ := i.hits.LoadOrStore(object, &instanceHit{i, object})
+	return h.(*instanceHit)
+}
+
+// UpdateBounds recomputes the bounds of the shared geometry if it is a group, which is needed
+// after moving primitives within it.
+func (i *Instance) UpdateBounds() {
+	if g, ok := i.shared.(*Group); ok {
+		g.UpdateBounds()
+	}
+}
+
+// Bounds returns the bounds of the shared geometry in the instance's space.
+func (i *Instance) Bounds() *BoundingBox {
+	return parentBounds(i.shared)
+}
+
+// instanceHit is the object of an intersection with a primitive of an instance's shared
+// geometry, which converts between world space and the primitive's space through the instance.
+type instanceHit struct {
+	instance *Instance
+	object   Primitive
+}
+
+func (h *instanceHit) Material() material.Material {
+	if !h.instance.useParentMaterial || inheritsFromRoot(h.object) {
+		return h.instance.Material()
+	}
+	return h.object.Material()
+}
+
+// inheritsFromRoot reports whether p has no material of its own or from a group it is in.
+func inheritsFromRoot(p Primitive) bool {
+	for p.inheritsMaterial() {
+		parent := p.Parent()
+		if parent == nil {
+			return true
+		}
+		p = parent
+	}
+	return false
+}
+
+func (h *instanceHit) inheritsMaterial() bool {
+	return h.instance.useParentMaterial && inheritsFromRoot(h.object)
+}
+
+func (h *instanceHit) Transform() matrix.Matrix {
+	return h.object.Transform()
+}
+
+func (h *instanceHit) InverseTransform() matrix.Matrix {
+	return h.object.InverseTransform()
+}
+
+func (h *instanceHit) SetMaterial(m material.Material) {
+	panic("can't set the material of an instanced primitive")
+}
+
+func (h *instanceHit) SetTransform(t matrix.Matrix) {
+	panic("can't set the transform of an instanced primitive")
+}
+
+func (h *instanceHit) SetMotion(m transform.Motion) {
+	panic("can't set the motion of an instanced primitive")
+}
+
+func (h *instanceHit) Parent() *Group {
+	return h.instance.Parent()
+}
+
+func (h *instanceHit) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
+	xn.object = h.object
+	normal := h.object.NormalAt(h.instance.worldPointToLocalAt(worldPoint, xn.time), xn)
+	return h.instance.localNormalToWorldAt(normal, xn.time)
+}
+
+func (h *instanceHit) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return h.object.UVAt(h.instance.WorldPointToLocal(worldPoint))
+}
+
+func (h *instanceHit) Intersects(worldRay ray.Ray) Intersections {
+	panic("can't intersect an instanced primitive on its own")
+}
+
+func (h *instanceHit) WorldPointToLocal(worldPoint tuple.Tuple) tuple.Tuple {
+	return h.object.WorldPointToLocal(h.instance.WorldPointToLocal(worldPoint))
+}
+
+func (h *instanceHit) Bounds() *BoundingBox {
+	return h.object.Bounds()
+}
+
+func (h *instanceHit) setParent(g *Group) {
+	panic("can't add an instanced primitive to a group")
+}
+
+func (h *instanceHit) keyTransforms() []matrix.Matrix {
+	return h.object.keyTransforms()
+}
+
+func (h *instanceHit) moving() bool {
+	return h.instance.moving() || h.object.moving()
+}
+
+func (h *instanceHit) restPoint(worldPoint tuple.Tuple, time float64) tuple.Tuple {
+	sharedPoint := h.object.restPoint(h.instance.worldPointToLocalAt(worldPoint, time), time)
+	return h.instance.localPointToWorld(sharedPoint)
+}
@@ -0,0 +1,146 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/material"
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInstancesShareGeometry(t *testing.T) {
+	s := NewSphere()
+	i1 := NewInstance(&s)
+	i1.SetTransform(transform.Translation(-5, 0, 0))
+	i2 := NewInstance(&s)
+	i2.SetTransform(transform.Translation(5, 0, 0))
+	g := NewGroup()
+	g.Add(i1, i2)
+
+	xs := g.Intersects(ray.New(tuple.NewPoint(5, 0, -5), tuple.NewVector(0, 0, 1)))
+
+	require.Len(t, xs, 2)
+	assert.Equal(t, 4.0, xs[0].distance)
+	assert.Equal(t, 6.0, xs[1].distance)
+	assert.Nil(t, s.Parent())
+	hit := xs[0].object.(*instanceHit)
+	assert.Same(t, i2, hit.instance)
+	assert.Same(t, Primitive(&s), hit.object)
+}
+
+func TestInstanceHitsAreTheSameObject(t *testing.T) {
+	s := NewSphere()
+	i := NewInstance(&s)
+
+	xs := i.Intersects(ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1)))
+	ys := i.Intersects(ray.New(tuple.NewPoint(0, 0.5, -5), tuple.NewVector(0, 0, 1)))
+
+	require.Len(t, xs, 2)
+	require.Len(t, ys, 2)
+	assert.True(t, xs[0].object == xs[1].object)
+	assert.True(t, xs[0].object == ys[0].object)
+}
+
+func TestInstanceOfPrimitiveInGroupPanics(t *testing.T) {
+	s := NewSphere()
+	NewGroup().Add(&s)
+
+	assert.Panics(t, func() { NewInstance(&s) })
+}
+
+func TestNormalOnInstance(t *testing.T) {
+	s := NewSphere()
+	s.SetTransform(transform.Translation(5, 0, 0))
+	i := NewInstance(&s)
+	i.SetTransform(transform.Scaling(1, 2, 3))
+	g := NewGroup()
+	g.SetTransform(transform.RotationY(math.Pi / 2))
+	g.Add(i)
+
+	// The same as TestNormalOnChildObject, with the instance in place of the inner group.
+	xs := g.Intersects(ray.New(tuple.NewPoint(0, 0, -20), tuple.NewVector(0, 0, 1)))
+	require.NotEmpty(t, xs)
+	n := xs[0].object.NormalAt(tuple.NewPoint(1.7321, 1.1547, -5.5774), xs[0])
+
+	test.AssertAlmost(t, tuple.NewVector(0.2857, 0.4286, -0.8571), n)
+}
+
+func TestInstanceWorldPointToLocal(t *testing.T) {
+	s := NewSphere()
+	s.SetTransform(transform.Scaling(2, 2, 2))
+	i := NewInstance(&s)
+	i.SetTransform(transform.Translation(10, 0, 0))
+
+	xs := i.Intersects(ray.New(tuple.NewPoint(10, 0, -5), tuple.NewVector(0, 0, 1)))
+
+	require.Len(t, xs, 2)
+	assert.Equal(t, tuple.NewPoint(0, 0, -1), xs[0].object.WorldPointToLocal(tuple.NewPoint(10, 0, -2)))
+	u, v := xs[0].object.UVAt(tuple.NewPoint(10, 0, -2))
+	expectedU, expectedV := sphericalUV(tuple.NewPoint(0, 0, -1))
+	assert.Equal(t, expectedU, u)
+	assert.Equal(t, expectedV, v)
+}
+
+func TestInstanceMaterial(t *testing.T) {
+	red := material.Default.WithColor(floatcolor.NewFromInt(0xff0000))
+	blue := material.Default.WithColor(floatcolor.NewFromInt(0x0000ff))
+	s := NewSphere()
+	s.SetMaterial(red)
+	plain := NewInstance(&s)
+	painted := NewInstance(&s)
+	painted.SetMaterial(blue)
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	assert.Equal(t, red, plain.Intersects(r)[0].object.Material())
+	assert.Equal(t, blue, painted.Intersects(r)[0].object.Material())
+}
+
+func TestInstanceInheritsMaterialOfItsGroup(t *testing.T) {
+	red := material.Default.WithColor(floatcolor.NewFromInt(0xff0000))
+	blue := material.Default.WithColor(floatcolor.NewFromInt(0x0000ff))
+	plainSphere := NewSphere()
+	blueSphere := NewSphere()
+	blueSphere.SetMaterial(blue)
+	g := NewGroup()
+	g.SetMaterial(red)
+	plain := NewInstance(&plainSphere)
+	painted := NewInstance(&blueSphere)
+	g.Add(plain, painted)
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	assert.Equal(t, red, plain.Intersects(r)[0].object.Material())
+	assert.Equal(t, blue, painted.Intersects(r)[0].object.Material())
+}
+
+func TestInstanceOfMesh(t *testing.T) {
+	m := squareMesh()
+	i := NewInstance(m)
+	i.SetTransform(transform.Translation(0, 0, 1))
+
+	xs := i.Intersects(ray.New(tuple.NewPoint(0.25, 0.75, -2), tuple.NewVector(0, 0, 1)))
+
+	require.Len(t, xs, 1)
+	assert.Equal(t, 3.0, xs[0].distance)
+	assert.Equal(t, 1, xs[0].face)
+	assert.Equal(t, tuple.NewVector(0, 0, -1), xs[0].object.NormalAt(tuple.NewPoint(0.25, 0.75, 1), xs[0]))
+}
+
+func TestInstanceBounds(t *testing.T) {
+	s := NewSphere()
+	s.SetTransform(transform.Translation(2, 0, 0))
+	i := NewInstance(&s)
+	i.SetTransform(transform.Scaling(2, 2, 2))
+	g := NewGroup()
+	g.Add(i)
+
+	assert.Equal(t, tuple.NewPoint(1, -1, -1), i.Bounds().Min())
+	assert.Equal(t, tuple.NewPoint(3, 1, 1), i.Bounds().Max())
+	assert.Equal(t, tuple.NewPoint(2, -2, -2), g.Bounds().Min())
+	assert.Equal(t, tuple.NewPoint(6, 2, 2), g.Bounds().Max())
+}
@@ -2,15 +2,19 @@ package primitive
 
 type Intersection struct {
 	distance, u, v float64
-	object         Primitive
+	// face is the index of the face hit within a Mesh.
+	face int
+	// time is the time of the ray, which places moving objects.
+	time   float64
+	object Primitive
 }
 
 func NewIntersection(distance float64, object Primitive) Intersection {
-	return Intersection{distance, 0, 0, object}
+	return Intersection{distance, 0, 0, 0, 0, object}
 }
 
 func NewIntersectionWithUV(distance, u, v float64, object Primitive) Intersection {
-	return Intersection{distance, u, v, object}
+	return Intersection{distance, u, v, 0, 0, object}
 }
 
 func (i Intersection) Distance() float64 {
@@ -0,0 +1,330 @@
+package primitive
+
+import (
+	"math"
+	"sort"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// meshLeafSize is the most faces kept in a leaf of a mesh's bounding volume hierarchy.
+const meshLeafSize = 4
+
+// TextureCoord is a two-dimensional texture coordinate for a mesh vertex.
+type TextureCoord struct {
+	U, V float64
+}
+
+// MeshFace is a triangle of a Mesh, given as indices into its vertices, and optionally into its
+// normals and texture coordinates. An index of -1 means the face has none, and a face must
+// have all three or none of each.
+type MeshFace struct {
+	Vertices, Normals, TextureCoords [3]int
+}
+
+// meshFace is MeshFace with smaller indices, to save memory in large meshes.
+type meshFace struct {
+	vertices, normals, textureCoords [3]int32
+}
+
+// meshNode is a node of a mesh's bounding volume hierarchy, stored in a flat slice. A leaf holds
+// count faces starting at start in the mesh's face order. An interior node has a count of 0, its
+// first child immediately after it and its second child at start.
+type meshNode struct {
+	bounds       BoundingBox
+	start, count int32
+}
+
+// Mesh is a set of triangles sharing arrays of vertices, normals and texture coordinates, which
+// uses far less memory than a Group of separate triangles. Faces are found by searching a
+// bounding volume hierarchy built when the mesh is created.
+type Mesh struct {
+	vertices      []tuple.Tuple
+	normals       []tuple.Tuple
+	textureCoords []TextureCoord
+	faces         []meshFace
+	order         []int32
+	nodes         []meshNode
+	tolerance     float64
+	data
+}
+
+// NewMesh creates a Mesh from faces indexing into vertices, normals and textureCoords. Faces
+// with normals are smooth shaded by interpolating them, and faces with texture coordinates
+// interpolate them for UVAt. The slices are kept rather than copied, so meshes can share them.
+func NewMesh(vertices, normals []tuple.Tuple, textureCoords []TextureCoord, faces []MeshFace) *Mesh {
+	m := &Mesh{
+		vertices:      vertices,
+		normals:       normals,
+		textureCoords: textureCoords,
+		faces:         make([]meshFace, len(faces)),
+		order:         make([]int32, len(faces)),
+		data:          newData(),
+	}
+	for i, f := range faces {
+		m.faces[i] = meshFace{
+			checkIndices(f.Vertices, len(vertices), false, "vertex"),
+			checkIndices(f.Normals, len(normals), true, "normal"),
+			checkIndices(f.TextureCoords, len(textureCoords), true, "texture coordinate"),
+		}
+		m.order[i] = int32(i)
+	}
+	m.build()
+	return m
+}
+
+func checkIndices(indices [3]int, count int, optional bool, kind string) [3]int32 {
+	if optional && indices == [3]int{-1, -1, -1} {
+		return [3]int32{-1, -1, -1}
+	}
+	var result [3]int32
+	for i, index := range indices {
+		if index < 0 || index >= count {
+			panic("mesh face has invalid " + kind + " index")
+		}
+		result[i] = int32(index)
+	}
+	return result
+}
+
+// FaceCount returns the number of faces in the mesh.
+func (m *Mesh) FaceCount() int {
+	return len(m.faces)
+}
+
+// Face returns face i, in the order given to NewMesh.
+func (m *Mesh) Face(i int) MeshFace {
+	f := m.faces[i]
+	var result MeshFace
+	for k := 0; k < 3; k++ {
+		result.Vertices[k] = int(f.vertices[k])
+		result.Normals[k] = int(f.normals[k])
+		result.TextureCoords[k] = int(f.textureCoords[k])
+	}
+	return result
+}
+
+// FaceVertices returns the corners of face i.
+func (m *Mesh) FaceVertices(i int) (tuple.Tuple, tuple.Tuple, tuple.Tuple) {
+	v := m.faces[i].vertices
+	return m.vertices[v[0]], m.vertices[v[1]], m.vertices[v[2]]
+}
+
+// FaceNormals returns the normals at the corners of face i, if it has them.
+func (m *Mesh) FaceNormals(i int) (n1, n2, n3 tuple.Tuple, ok bool) {
+	n := m.faces[i].normals
+	if n[0] < 0 {
+		return tuple.Tuple{}, tuple.Tuple{}, tuple.Tuple{}, false
+	}
+	return m.normals[n[0]], m.normals[n[1]], m.normals[n[2]], true
+}
+
+func (m *Mesh) Intersects(worldRay ray.Ray) Intersections {
+	return m.worldIntersects(worldRay, m)
+}
+
+func (m *Mesh) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
+	return m.worldNormalAt(worldPoint, xn, m)
+}
+
+func (m *Mesh) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return m.worldUVAt(worldPoint, m)
+}
+
+func (m *Mesh) localIntersects(localRay ray.Ray) Intersections {
+	if len(m.nodes) == 0 {
+		return nil
+	}
+
+	var xs Intersections
+	stack := make([]int32, 1, 64)
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		node := &m.nodes[n]
+		if !node.bounds.intersects(localRay) {
+			continue
+		}
+		if node.count == 0 {
+			stack = append(stack, node.start, n+1)
+			continue
+		}
+		for _, face := range m.order[node.start : node.start+node.count] {
+			p1, p2, p3 := m.FaceVertices(int(face))
+			d, u, v, ok := triangleHit(localRay, p1, p2.Sub(p1), p3.Sub(p1))
+			if ok {
+				xs = append(xs, Intersection{distance: d, u: u, v: v, face: int(face), object: m})
+			}
+		}
+	}
+
+	sort.Slice(xs, func(i, j int) bool { return xs[i].distance < xs[j].distance })
+	return xs
+}
+
+// localNormalAt interpolates the normals of the face hit, or returns its geometric normal if
+// it has none. Without an intersection with the mesh, the face is found from localPoint.
+func (m *Mesh) localNormalAt(localPoint tuple.Tuple, xn Intersection) tuple.Tuple {
+	face, u, v := xn.face, xn.u, xn.v
+	if xn.object != m {
+		var ok bool
+		if face, u, v, ok = m.faceAt(localPoint); !ok {
+			return tuple.NewVector(0, 1, 0)
+		}
+	}
+
+	f := m.faces[face]
+	if f.normals[0] < 0 {
+		p1, p2, p3 := m.FaceVertices(face)
+		return p3.Sub(p1).Cross(p2.Sub(p1)).Norm()
+	}
+	n1, n2, n3 := m.normals[f.normals[0]], m.normals[f.normals[1]], m.normals[f.normals[2]]
+	return n2.Mul(u).Add(n3.Mul(v)).Add(n1.Mul(1 - u - v))
+}
+
+// localUVAt interpolates the texture coordinates of the face at localPoint, or if it has none
+// returns the weights of its second and third vertices like a Triangle.
+func (m *Mesh) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	face, u, v, ok := m.faceAt(localPoint)
+	if !ok {
+		return 0, 0
+	}
+	t := m.faces[face].textureCoords
+	if t[0] < 0 {
+		return u, v
+	}
+	t1, t2, t3 := m.textureCoords[t[0]], m.textureCoords[t[1]], m.textureCoords[t[2]]
+	w := 1 - u - v
+	return t1.U*w + t2.U*u + t3.U*v, t1.V*w + t2.V*u + t3.V*v
+}
+
+// faceAt finds the face closest to localPoint among those it lies within, returning the weights
+// of the face's second and third vertices at the point.
+func (m *Mesh) faceAt(localPoint tuple.Tuple) (face int, u, v float64, ok bool) {
+	if len(m.nodes) == 0 {
+		return 0, 0, 0, false
+	}
+
+	bestDistance := math.Inf(1)
+	stack := make([]int32, 1, 64)
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		node := &m.nodes[n]
+		if !node.bounds.containsPointWithin(localPoint, m.tolerance) {
+			continue
+		}
+		if node.count == 0 {
+			stack = append(stack, node.start, n+1)
+			continue
+		}
+		for _, f := range m.order[node.start : node.start+node.count] {
+			p1, p2, p3 := m.FaceVertices(int(f))
+			e1, e2 := p2.Sub(p1), p3.Sub(p1)
+			fu, fv := barycentricUV(localPoint, p1, e1, e2)
+			if fu < -m.tolerance || fv < -m.tolerance || fu+fv > 1+m.tolerance {
+				continue
+			}
+			normal := e2.Cross(e1)
+			if normal.Mag() == 0 {
+				continue
+			}
+			distance := math.Abs(localPoint.Sub(p1).Dot(normal.Norm()))
+			if distance < bestDistance {
+				bestDistance = distance
+				face, u, v, ok = int(f), fu, fv, true
+			}
+		}
+	}
+	return face, u, v, ok
+}
+
+func (m *Mesh) Bounds() *BoundingBox {
+	if len(m.nodes) == 0 {
+		return NewEmptyBoundingBox()
+	}
+	return &m.nodes[0].bounds
+}
+
+// build creates the bounding volume hierarchy, splitting the faces at the median of their
+// centers along the longest axis of the box around those centers.
+func (m *Mesh) build() {
+	if len(m.faces) == 0 {
+		return
+	}
+	centers := make([]tuple.Tuple, len(m.faces))
+	for i := range m.faces {
+		p1, p2, p3 := m.FaceVertices(i)
+		centers[i] = tuple.NewPoint((p1.X+p2.X+p3.X)/3, (p1.Y+p2.Y+p3.Y)/3, (p1.Z+p2.Z+p3.Z)/3)
+	}
+	m.nodes = make([]meshNode, 0, 2*len(m.faces)/meshLeafSize+1)
+	m.buildNode(centers, 0, len(m.faces))
+
+	min, max := m.nodes[0].bounds.min, m.nodes[0].bounds.max
+	m.tolerance = 1e-7 * (1 + max.Sub(min).Mag())
+}
+
+func (m *Mesh) buildNode(centers []tuple.Tuple, start, end int) {
+	bounds := NewEmptyBoundingBox()
+	centerBounds := NewEmptyBoundingBox()
+	for _, f := range m.order[start:end] {
+		p1, p2, p3 := m.FaceVertices(int(f))
+		bounds.AddPoint(p1)
+		bounds.AddPoint(p2)
+		bounds.AddPoint(p3)
+		centerBounds.AddPoint(centers[f])
+	}
+
+	n := len(m.nodes)
+	m.nodes = append(m.nodes, meshNode{bounds: *bounds})
+	extent := centerBounds.max.Sub(centerBounds.min)
+	if end-start <= meshLeafSize || extent.Mag() == 0 {
+		m.nodes[n].start, m.nodes[n].count = int32(start), int32(end-start)
+		return
+	}
+
+	axis := func(p tuple.Tuple) float64 { return p.X }
+	if extent.Y > extent.X && extent.Y >= extent.Z {
+		axis = func(p tuple.Tuple) float64 { return p.Y }
+	} else if extent.Z > extent.X && extent.Z > extent.Y {
+		axis = func(p tuple.Tuple) float64 { return p.Z }
+	}
+	mid := (start + end) / 2
+	selectNth(m.order[start:end], mid-start, func(f int32) float64 { return axis(centers[f]) })
+
+	m.buildNode(centers, start, mid)
+	m.nodes[n].start = int32(len(m.nodes))
+	m.buildNode(centers, mid, end)
+}
+
+// selectNth reorders faces so that the one with the nth smallest key is at n, with smaller keys
+// before it and larger keys after.
+func selectNth(faces []int32, n int, key func(f int32) float64) {
+	lo, hi := 0, len(faces)-1
+	for lo < hi {
+		pivot := key(faces[(lo+hi)/2])
+		i, j := lo, hi
+		for i <= j {
+			for key(faces[i]) < pivot {
+				i++
+			}
+			for key(faces[j]) > pivot {
+				j--
+			}
+			if i <= j {
+				faces[i], faces[j] = faces[j], faces[i]
+				i++
+				j--
+			}
+		}
+		switch {
+		case n <= j:
+			hi = j
+		case n >= i:
+			lo = i
+		default:
+			return
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package primitive
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func noIndices() [3]int {
+	return [3]int{-1, -1, -1}
+}
+
+// squareMesh is a unit square on the x-y plane at z=0 made of two triangles.
+func squareMesh() *Mesh {
+	vertices := []tuple.Tuple{
+		tuple.NewPoint(0, 0, 0),
+		tuple.NewPoint(1, 0, 0),
+		tuple.NewPoint(1, 1, 0),
+		tuple.NewPoint(0, 1, 0),
+	}
+	faces := []MeshFace{
+		{[3]int{0, 1, 2}, noIndices(), noIndices()},
+		{[3]int{0, 2, 3}, noIndices(), noIndices()},
+	}
+	return NewMesh(vertices, nil, nil, faces)
+}
+
+func TestMeshFaces(t *testing.T) {
+	m := squareMesh()
+
+	assert.Equal(t, 2, m.FaceCount())
+	assert.Equal(t, MeshFace{[3]int{0, 2, 3}, noIndices(), noIndices()}, m.Face(1))
+	p1, p2, p3 := m.FaceVertices(1)
+	assert.Equal(t, tuple.NewPoint(0, 0, 0), p1)
+	assert.Equal(t, tuple.NewPoint(1, 1, 0), p2)
+	assert.Equal(t, tuple.NewPoint(0, 1, 0), p3)
+	_, _, _, ok := m.FaceNormals(1)
+	assert.False(t, ok)
+}
+
+func TestMeshWithInvalidIndexPanics(t *testing.T) {
+	vertices := []tuple.Tuple{tuple.NewPoint(0, 0, 0), tuple.NewPoint(1, 0, 0), tuple.NewPoint(0, 1, 0)}
+
+	assert.Panics(t, func() {
+		NewMesh(vertices, nil, nil, []MeshFace{{[3]int{0, 1, 3}, noIndices(), noIndices()}})
+	})
+	assert.Panics(t, func() {
+		NewMesh(vertices, nil, nil, []MeshFace{{[3]int{0, 1, 2}, [3]int{0, 0, 0}, noIndices()}})
+	})
+	assert.Panics(t, func() {
+		NewMesh(vertices, nil, nil, []MeshFace{{[3]int{0, 1, 2}, noIndices(), [3]int{0, -1, -1}}})
+	})
+}
+
+func TestMeshBounds(t *testing.T) {
+	m := squareMesh()
+
+	assert.Equal(t, tuple.NewPoint(0, 0, 0), m.Bounds().Min())
+	assert.Equal(t, tuple.NewPoint(1, 1, 0), m.Bounds().Max())
+	assert.Equal(t, NewEmptyBoundingBox(), NewMesh(nil, nil, nil, nil).Bounds())
+}
+
+func TestRayIntersectsMesh(t *testing.T) {
+	m := squareMesh()
+
+	xs := m.localIntersects(ray.New(tuple.NewPoint(0.25, 0.75, -2), tuple.NewVector(0, 0, 1)))
+
+	require.Len(t, xs, 1)
+	assert.Equal(t, 2.0, xs[0].distance)
+	assert.Equal(t, 1, xs[0].face)
+	assert.Same(t, m, xs[0].object)
+	test.AssertAlmost(t, 0.25, xs[0].u)
+	test.AssertAlmost(t, 0.5, xs[0].v)
+}
+
+func TestRayMissesMesh(t *testing.T) {
+	m := squareMesh()
+
+	xs := m.localIntersects(ray.New(tuple.NewPoint(1.5, 0.5, -2), tuple.NewVector(0, 0, 1)))
+
+	assert.Empty(t, xs)
+}
+
+func TestMeshIntersectionsMatchTriangles(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var vertices []tuple.Tuple
+	var faces []MeshFace
+	var triangles []Triangle
+	for i := 0; i < 200; i++ {
+		center := tuple.NewPoint(rng.Float64()*10-5, rng.Float64()*10-5, rng.Float64()*10-5)
+		var corners [3]int
+		for k := range corners {
+			corners[k] = len(vertices)
+			vertices = append(vertices, center.Add(tuple.NewVector(rng.Float64()-0.5, rng.Float64()-0.5, rng.Float64()-0.5)))
+		}
+		faces = append(faces, MeshFace{corners, noIndices(), noIndices()})
+		triangles = append(triangles, NewTriangle(vertices[corners[0]], vertices[corners[1]], vertices[corners[2]]))
+	}
+	m := NewMesh(vertices, nil, nil, faces)
+	require.Greater(t, len(m.nodes), 1)
+
+	for i := 0; i < 500; i++ {
+		origin := tuple.NewPoint(rng.Float64()*20-10, rng.Float64()*20-10, -20)
+		target := tuple.NewPoint(rng.Float64()*10-5, rng.Float64()*10-5, rng.Float64()*10-5)
+		r := ray.New(origin, target.Sub(origin).Norm())
+
+		var expected []float64
+		for _, tri := range triangles {
+			for _, x := range tri.localIntersects(r) {
+				expected = append(expected, x.distance)
+			}
+		}
+		xs := m.localIntersects(r)
+
+		require.Len(t, xs, len(expected))
+		for _, x := range xs {
+			assert.Contains(t, expected, x.distance)
+		}
+		for k := 1; k < len(xs); k++ {
+			assert.LessOrEqual(t, xs[k-1].distance, xs[k].distance)
+		}
+	}
+}
+
+func TestMeshFlatNormal(t *testing.T) {
+	m := squareMesh()
+	tri := NewTriangle(m.FaceVertices(0))
+
+	n := m.localNormalAt(tuple.NewPoint(0.75, 0.25, 0), Intersection{})
+
+	assert.Equal(t, tri.normal.Norm(), n)
+}
+
+func TestMeshInterpolatesNormals(t *testing.T) {
+	vertices := []tuple.Tuple{tuple.NewPoint(0, 1, 0), tuple.NewPoint(-1, 0, 0), tuple.NewPoint(1, 0, 0)}
+	normals := []tuple.Tuple{tuple.NewVector(0, 1, 0), tuple.NewVector(-1, 0, 0), tuple.NewVector(1, 0, 0)}
+	m := NewMesh(vertices, normals, nil, []MeshFace{{[3]int{0, 1, 2}, [3]int{0, 1, 2}, noIndices()}})
+	smooth := NewSmoothTriangle(vertices[0], vertices[1], vertices[2], normals[0], normals[1], normals[2])
+
+	xn := NewIntersectionWithUV(1, 0.45, 0.25, m)
+	n := m.NormalAt(tuple.NewPoint(0, 0, 0), xn)
+
+	test.AssertAlmost(t, smooth.NormalAt(tuple.NewPoint(0, 0, 0), NewIntersectionWithUV(1, 0.45, 0.25, &smooth)), n)
+	// Without an intersection with the mesh, the face is found from the point.
+	test.AssertAlmost(t, tuple.NewVector(0, 1, 0), m.localNormalAt(tuple.NewPoint(0, 1, 0), Intersection{}))
+}
+
+func TestMeshNormalUsesFaceHit(t *testing.T) {
+	vertices := []tuple.Tuple{
+		tuple.NewPoint(0, 0, 0),
+		tuple.NewPoint(1, 0, 0),
+		tuple.NewPoint(0, 1, 0),
+		tuple.NewPoint(0, 0, 1),
+	}
+	faces := []MeshFace{
+		{[3]int{0, 1, 2}, noIndices(), noIndices()},
+		{[3]int{0, 3, 1}, noIndices(), noIndices()},
+	}
+	m := NewMesh(vertices, nil, nil, faces)
+
+	// Both faces touch the edge from vertex 0 to vertex 1, so the face must come from the hit.
+	xs := m.localIntersects(ray.New(tuple.NewPoint(0.2, 0.2, -1), tuple.NewVector(0, 0, 1)))
+	require.Len(t, xs, 1)
+	assert.Equal(t, 0, xs[0].face)
+	edge := tuple.NewPoint(0.5, 0, 0)
+	assert.Equal(t, tuple.NewVector(0, 0, -1), m.localNormalAt(edge, xs[0]))
+	assert.Equal(t, tuple.NewVector(0, -1, 0), m.localNormalAt(edge, Intersection{distance: 1, u: 0.5, face: 1, object: m}))
+}
+
+func TestMeshUVWithoutTextureCoords(t *testing.T) {
+	m := squareMesh()
+
+	u, v := m.UVAt(tuple.NewPoint(0.75, 0.25, 0))
+
+	test.AssertAlmost(t, 0.5, u)
+	test.AssertAlmost(t, 0.25, v)
+}
+
+func TestMeshUVInterpolatesTextureCoords(t *testing.T) {
+	vertices := []tuple.Tuple{tuple.NewPoint(0, 0, 0), tuple.NewPoint(2, 0, 0), tuple.NewPoint(0, 2, 0)}
+	uvs := []TextureCoord{{0, 0}, {1, 0}, {0, 0.5}}
+	m := NewMesh(vertices, nil, uvs, []MeshFace{{[3]int{0, 1, 2}, noIndices(), [3]int{0, 1, 2}}})
+
+	u, v := m.UVAt(tuple.NewPoint(1, 1, 0))
+
+	test.AssertAlmost(t, 0.5, u)
+	test.AssertAlmost(t, 0.25, v)
+	u, v = m.UVAt(tuple.NewPoint(5, 5, 0))
+	assert.Equal(t, 0.0, u)
+	assert.Equal(t, 0.0, v)
+}
+
+func TestTransformedMeshInGroup(t *testing.T) {
+	m := squareMesh()
+	g := NewGroup()
+	g.SetTransform(transform.RotationY(math.Pi))
+	g.Add(m)
+
+	xs := g.Intersects(ray.New(tuple.NewPoint(-0.25, 0.25, 5), tuple.NewVector(0, 0, -1)))
+
+	require.Len(t, xs, 1)
+	test.AssertAlmost(t, 5.0, xs[0].Distance())
+	n := xs[0].Object().NormalAt(tuple.NewPoint(-0.25, 0.25, 0), xs[0])
+	test.AssertAlmost(t, tuple.NewVector(0, 0, 1), n)
+}
@@ -0,0 +1,198 @@
+package primitive
+
+import (
+	"sort"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Metaball is a point source of the field that defines the surface of Metaballs.
+type Metaball struct {
+	center         tuple.Tuple
+	radius, weight float64
+}
+
+// NewMetaball creates a Metaball at center whose field is weight at its center, falling
+// smoothly to zero at radius. A negative weight subtracts from the field, carving into other
+// balls.
+func NewMetaball(center tuple.Tuple, radius, weight float64) Metaball {
+	if radius <= 0 {
+		panic("metaball radius must be positive")
+	}
+	return Metaball{center, radius, weight}
+}
+
+func (b Metaball) Center() tuple.Tuple {
+	return b.center
+}
+
+func (b Metaball) Radius() float64 {
+	return b.radius
+}
+
+func (b Metaball) Weight() float64 {
+	return b.weight
+}
+
+// fieldAt returns the ball's contribution to the field at p, which is weight×(1-d²)³ where d is
+// the distance from the center as a fraction of the radius.
+func (b Metaball) fieldAt(p tuple.Tuple) float64 {
+	q := p.Sub(b.center).Mag() / b.radius
+	if q >= 1 {
+		return 0
+	}
+	g := 1 - q*q
+	return b.weight * g * g * g
+}
+
+// Metaballs is a blobby surface where the sum of the fields of a set of balls equals a
+// threshold, so nearby balls merge smoothly into each other.
+type Metaballs struct {
+	balls     []Metaball
+	threshold float64
+	bounds    *BoundingBox
+	data
+}
+
+// NewMetaballs creates Metaballs whose surface is where the field of balls equals threshold,
+// which must be positive. A single ball of weight w and radius r on its own gives a sphere of
+// radius r×sqrt(1-cbrt(threshold/w)).
+func NewMetaballs(threshold float64, balls ...Metaball) Metaballs {
+	if threshold <= 0 {
+		panic("metaballs threshold must be positive")
+	}
+	// The field only reaches the threshold within reach of a ball that adds to it.
+	bounds := NewEmptyBoundingBox()
+	for _, b := range balls {
+		if b.weight > 0 {
+			r := tuple.NewVector(b.radius, b.radius, b.radius)
+			bounds.AddPoint(b.center.Sub(r))
+			bounds.AddPoint(b.center.Add(r))
+		}
+	}
+	return Metaballs{balls, threshold, bounds, newData()}
+}
+
+func (m *Metaballs) Balls() []Metaball {
+	return m.balls
+}
+
+func (m *Metaballs) Threshold() float64 {
+	return m.threshold
+}
+
+// FieldAt returns the sum of the fields of the balls at localPoint, in the object's space.
+func (m *Metaballs) FieldAt(localPoint tuple.Tuple) float64 {
+	field := 0.0
+	for _, b := range m.balls {
+		field += b.fieldAt(localPoint)
+	}
+	return field
+}
+
+func (m *Metaballs) Intersects(worldRay ray.Ray) Intersections {
+	return m.worldIntersects(worldRay, m)
+}
+
+func (m *Metaballs) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
+	return m.worldNormalAt(worldPoint, xn, m)
+}
+
+func (m *Metaballs) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return m.worldUVAt(worldPoint, m)
+}
+
+// metaballSpan is the part of a ray, as distances along it, within reach of a ball.
+type metaballSpan struct {
+	ball       Metaball
+	start, end float64
+}
+
+// localIntersects splits the ray where it enters and leaves the reach of each ball. Between
+// those points the field along the ray is a polynomial of degree 6, whose roots are the hits.
+func (m *Metaballs) localIntersects(localRay ray.Ray) Intersections {
+	if !m.bounds.intersects(localRay) {
+		return nil
+	}
+
+	// Work along a unit direction to keep the polynomials well conditioned, then convert back
+	// to distances along the original ray.
+	length := localRay.Direction().Mag()
+	direction := localRay.Direction().Mul(1 / length)
+
+	var spans []metaballSpan
+	var breaks []float64
+	for _, b := range m.balls {
+		toCenter := localRay.Origin().Sub(b.center)
+		roots := quadraticRoots(1, 2*direction.Dot(toCenter), toCenter.Dot(toCenter)-b.radius*b.radius)
+		if len(roots) == 2 {
+			spans = append(spans, metaballSpan{b, roots[0], roots[1]})
+			breaks = append(breaks, roots[0], roots[1])
+		}
+	}
+	sort.Float64s(breaks)
+
+	var xs Intersections
+	for i := 0; i < len(breaks)-1; i++ {
+		start, end := breaks[i], breaks[i+1]
+		if start == end {
+			continue
+		}
+		origin := localRay.Origin().Add(direction.Mul(start))
+		field := []float64{-m.threshold}
+		for _, s := range spans {
+			if s.start <= start && s.end >= end {
+				field = addPolynomials(field, s.ball.fieldAlong(origin, direction))
+			}
+		}
+		for _, r := range polynomialRoots(field...) {
+			if r >= 0 && r < end-start {
+				xs = append(xs, NewIntersection((start+r)/length, m))
+			}
+		}
+	}
+	return xs
+}
+
+// fieldAlong returns the coefficients, highest power first, of the ball's field at distance t
+// along the ray from origin in the unit direction, assuming the whole ray is within its reach.
+func (b Metaball) fieldAlong(origin, direction tuple.Tuple) []float64 {
+	toCenter := origin.Sub(b.center)
+	r2 := b.radius * b.radius
+	// 1 - d² as a quadratic in t.
+	g := []float64{-1 / r2, -2 * direction.Dot(toCenter) / r2, 1 - toCenter.Dot(toCenter)/r2}
+	cubed := multiplyPolynomials(multiplyPolynomials(g, g), g)
+	for i := range cubed {
+		cubed[i] *= b.weight
+	}
+	return cubed
+}
+
+// localNormalAt points against the gradient of the field, towards where it falls below the
+// threshold.
+func (m *Metaballs) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple.Tuple {
+	normal := tuple.NewVector(0, 0, 0)
+	for _, b := range m.balls {
+		offset := localPoint.Sub(b.center)
+		q := offset.Mag() / b.radius
+		if q >= 1 {
+			continue
+		}
+		g := 1 - q*q
+		normal = normal.Add(offset.Mul(6 * b.weight * g * g / (b.radius * b.radius)))
+	}
+	if normal.Mag() == 0 {
+		return normal
+	}
+	return normal.Norm()
+}
+
+// localUVAt projects the surface onto a sphere around the origin.
+func (m *Metaballs) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	return sphericalUV(localPoint)
+}
+
+func (m *Metaballs) Bounds() *BoundingBox {
+	return m.bounds
+}
@@ -0,0 +1,113 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSingleMetaballIsASphere(t *testing.T) {
+	// With the threshold at 1/8 of the weight the surface is where 1-d² = 1/2.
+	m := NewMetaballs(0.125, NewMetaball(tuple.NewPoint(0, 0, 0), 2, 1))
+	r := math.Sqrt2
+
+	examples := []struct {
+		origin, direction tuple.Tuple
+		ts                []float64
+	}{
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1), []float64{5 - r, 5 + r}},
+		{tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 2, 0), []float64{-r / 2, r / 2}},
+		{tuple.NewPoint(0, 1.5, -5), tuple.NewVector(0, 0, 1), []float64{}},
+		{tuple.NewPoint(0, 5, -5), tuple.NewVector(0, 0, 1), []float64{}},
+	}
+
+	for _, e := range examples {
+		ray := ray.New(e.origin, e.direction)
+		xs := m.localIntersects(ray)
+		require.Len(t, xs, len(e.ts))
+		for i := range e.ts {
+			test.AssertAlmost(t, e.ts[i], xs[i].distance)
+		}
+	}
+}
+
+func TestNearbyMetaballsMerge(t *testing.T) {
+	left := NewMetaball(tuple.NewPoint(-1.2, 0, 0), 2, 1)
+	right := NewMetaball(tuple.NewPoint(1.2, 0, 0), 2, 1)
+	r := ray.New(tuple.NewPoint(-5, 0, 0), tuple.NewVector(1, 0, 0))
+
+	apart := NewMetaballs(0.4, left, NewMetaball(tuple.NewPoint(4, 0, 0), 2, 1))
+	merged := NewMetaballs(0.4, left, right)
+
+	assert.Len(t, apart.localIntersects(r), 4)
+	xs := merged.localIntersects(r)
+	require.Len(t, xs, 2)
+	for _, x := range xs {
+		test.AssertAlmost(t, 0.4, merged.FieldAt(r.Position(x.distance)))
+	}
+}
+
+func TestNegativeMetaballCarvesHole(t *testing.T) {
+	m := NewMetaballs(0.125,
+		NewMetaball(tuple.NewPoint(0, 0, 0), 2, 1),
+		NewMetaball(tuple.NewPoint(0, 0, 0), 0.5, -1),
+	)
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	xs := m.localIntersects(r)
+
+	require.Len(t, xs, 4)
+	for i, x := range xs {
+		test.AssertAlmost(t, 0.125, m.FieldAt(r.Position(x.distance)))
+		if i > 0 {
+			assert.Less(t, xs[i-1].distance, x.distance)
+		}
+	}
+}
+
+func TestIntersectingTransformedMetaballs(t *testing.T) {
+	m := NewMetaballs(0.125, NewMetaball(tuple.NewPoint(0, 0, 0), 2, 1))
+	m.SetTransform(transform.Translation(0, 0, 10))
+	r := ray.New(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, 1))
+
+	xs := m.Intersects(r)
+
+	require.Len(t, xs, 2)
+	test.AssertAlmost(t, 10-math.Sqrt2, xs[0].distance)
+}
+
+func TestNormalOnMetaballs(t *testing.T) {
+	single := NewMetaballs(0.125, NewMetaball(tuple.NewPoint(1, 0, 0), 2, 1))
+	pair := NewMetaballs(0.5,
+		NewMetaball(tuple.NewPoint(-1, 0, 0), 2, 1),
+		NewMetaball(tuple.NewPoint(1, 0, 0), 2, 1),
+	)
+
+	test.AssertAlmost(t, tuple.NewVector(0, 1, 0), single.localNormalAt(tuple.NewPoint(1, 1, 0), Intersection{}))
+	test.AssertAlmost(t, tuple.NewVector(-1, 0, 0), single.localNormalAt(tuple.NewPoint(0, 0, 0), Intersection{}))
+	test.AssertAlmost(t, tuple.NewVector(0, 0, 1), pair.localNormalAt(tuple.NewPoint(0, 0, 0.5), Intersection{}))
+}
+
+func TestMetaballsBoundsIgnoreNegativeBalls(t *testing.T) {
+	m := NewMetaballs(0.5,
+		NewMetaball(tuple.NewPoint(1, 0, 0), 1, 1),
+		NewMetaball(tuple.NewPoint(0, 2, 0), 0.5, 2),
+		NewMetaball(tuple.NewPoint(0, -5, 0), 3, -1),
+	)
+
+	b := m.Bounds()
+
+	assert.Equal(t, tuple.NewPoint(-0.5, -1, -1), b.min)
+	assert.Equal(t, tuple.NewPoint(2, 2.5, 1), b.max)
+}
+
+func TestMetaballsPanicWithInvalidArguments(t *testing.T) {
+	assert.Panics(t, func() { NewMetaballs(0) })
+	assert.Panics(t, func() { NewMetaball(tuple.NewPoint(0, 0, 0), 0, 1) })
+}
@@ -20,15 +20,19 @@ func (p *Plane) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
 	return p.worldNormalAt(worldPoint, xn, p)
 }
 
+func (p *Plane) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return p.worldUVAt(worldPoint, p)
+}
+
 func (p *Plane) Intersects(worldRay ray.Ray) Intersections {
 	return p.worldIntersects(worldRay, p)
 }
 
 func (p *Plane) localIntersects(localRay ray.Ray) Intersections {
-	if math.Abs(localRay.Direction().Y) < float.Epsilon {
+	distance, _, ok := xzPlaneIntersection(localRay, false)
+	if !ok {
 		return nil
 	}
-	distance := -localRay.Origin().Y / localRay.Direction().Y
 	return NewIntersections(NewIntersection(distance, p))
 }
 
@@ -36,6 +40,10 @@ func (p *Plane) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple.Tupl
 	return tuple.NewVector(0, 1, 0)
 }
 
+func (p *Plane) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	return planarUV(localPoint)
+}
+
 var planeBounds = NewBoundingBox(
 	tuple.NewPoint(math.Inf(-1), 0, math.Inf(-1)),
 	tuple.NewPoint(math.Inf(1), 0, math.Inf(1)),
@@ -44,3 +52,14 @@ var planeBounds = NewBoundingBox(
 func (p *Plane) Bounds() *BoundingBox {
 	return planeBounds
 }
+
+// xzPlaneIntersection finds where a ray crosses the x-z plane. If singleSided is set, only rays
+// travelling downwards onto the plane's upper side hit it.
+func xzPlaneIntersection(localRay ray.Ray, singleSided bool) (distance float64, point tuple.Tuple, ok bool) {
+	dy := localRay.Direction().Y
+	if math.Abs(dy) < float.Epsilon || (singleSided && dy > 0) {
+		return 0, tuple.Tuple{}, false
+	}
+	distance = -localRay.Origin().Y / dy
+	return distance, localRay.Position(distance), true
+}
@@ -0,0 +1,149 @@
+package primitive
+
+import (
+	"math"
+)
+
+// polynomialRoots returns the real roots of the polynomial with the given coefficients, highest
+// power first, in ascending order. Roots where the polynomial touches zero without crossing it
+// may be missed, which for intersections means rays grazing a surface miss it.
+//
+// Rather than closed form solutions, which lose precision badly for quartics, roots are found by
+// splitting the real line at the roots of the derivative into intervals where the polynomial is
+// monotonic, then searching each interval that contains a change of sign.
+func polynomialRoots(coeffs ...float64) []float64 {
+	for len(coeffs) > 0 && coeffs[0] == 0 {
+		coeffs = coeffs[1:]
+	}
+	switch len(coeffs) {
+	case 0, 1:
+		return nil
+	case 2:
+		return []float64{-coeffs[1] / coeffs[0]}
+	case 3:
+		return quadraticRoots(coeffs[0], coeffs[1], coeffs[2])
+	}
+
+	degree := len(coeffs) - 1
+	derivative := make([]float64, degree)
+	for i := range derivative {
+		derivative[i] = coeffs[i] * float64(degree-i)
+	}
+
+	// Cauchy's bound on the magnitude of any root.
+	bound := 0.0
+	for _, c := range coeffs[1:] {
+		bound = math.Max(bound, math.Abs(c/coeffs[0]))
+	}
+	bound++
+
+	bounds := []float64{-bound}
+	for _, r := range polynomialRoots(derivative...) {
+		if r > -bound && r < bound {
+			bounds = append(bounds, r)
+		}
+	}
+	bounds = append(bounds, bound)
+
+	var roots []float64
+	for i := 0; i < len(bounds)-1; i++ {
+		if r, ok := bracketedRoot(coeffs, derivative, bounds[i], bounds[i+1]); ok {
+			roots = append(roots, r)
+		}
+	}
+	return roots
+}
+
+// quadraticRoots returns the real roots of ax² + bx + c in ascending order, avoiding the
+// cancellation of the textbook formula.
+func quadraticRoots(a, b, c float64) []float64 {
+	discriminant := b*b - 4*a*c
+	if discriminant < 0 {
+		return nil
+	}
+	q := -0.5 * (b + math.Copysign(math.Sqrt(discriminant), b))
+	if q == 0 {
+		return []float64{0, 0}
+	}
+	r1, r2 := q/a, c/q
+	if r1 > r2 {
+		r1, r2 = r2, r1
+	}
+	return []float64{r1, r2}
+}
+
+// bracketedRoot finds a root between lo and hi, where the polynomial is monotonic, using Newton's
+// method and falling back to bisection when a step would leave the bracket.
+func bracketedRoot(coeffs, derivative []float64, lo, hi float64) (float64, bool) {
+	fLo, fHi := evaluate(coeffs, lo), evaluate(coeffs, hi)
+	if fLo == 0 {
+		return lo, true
+	}
+	if fHi == 0 {
+		return hi, true
+	}
+	if (fLo > 0) == (fHi > 0) {
+		return 0, false
+	}
+	// Orient the bracket so the polynomial is negative at lo.
+	if fLo > 0 {
+		lo, hi = hi, lo
+	}
+
+	x := (lo + hi) / 2
+	for i := 0; i < 100; i++ {
+		f := evaluate(coeffs, x)
+		if f == 0 {
+			return x, true
+		}
+		if f < 0 {
+			lo = x
+		} else {
+			hi = x
+		}
+		next := x - f/evaluate(derivative, x)
+		if math.IsNaN(next) || (next-lo)*(next-hi) >= 0 {
+			next = (lo + hi) / 2
+		}
+		if math.Abs(next-x) <= 1e-14*(1+math.Abs(x)) {
+			return next, true
+		}
+		x = next
+	}
+	return x, true
+}
+
+// evaluate returns the value of the polynomial with the given coefficients at x.
+func evaluate(coeffs []float64, x float64) float64 {
+	result := 0.0
+	for _, c := range coeffs {
+		result = result*x + c
+	}
+	return result
+}
+
+// multiplyPolynomials returns the product of two polynomials with coefficients highest power
+// first.
+func multiplyPolynomials(p, q []float64) []float64 {
+	product := make([]float64, len(p)+len(q)-1)
+	for i, a := range p {
+		for j, b := range q {
+			product[i+j] += a * b
+		}
+	}
+	return product
+}
+
+// addPolynomials returns the sum of two polynomials with coefficients highest power first.
+func addPolynomials(p, q []float64) []float64 {
+	if len(p) < len(q) {
+		p, q = q, p
+	}
+	sum := make([]float64, len(p))
+	copy(sum, p)
+	offset := len(p) - len(q)
+	for i, c := range q {
+		sum[offset+i] += c
+	}
+	return sum
+}
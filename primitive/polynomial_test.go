@@ -0,0 +1,68 @@
+package primitive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func assertRoots(t *testing.T, expected []float64, actual []float64) {
+	t.Helper()
+	require.Len(t, actual, len(expected))
+	for i := range expected {
+		assert.InDelta(t, expected[i], actual[i], 1e-9)
+	}
+}
+
+func TestLinearRoot(t *testing.T) {
+	assertRoots(t, []float64{-1.5}, polynomialRoots(2, 3))
+}
+
+func TestQuadraticRoots(t *testing.T) {
+	assertRoots(t, []float64{-3, 2}, polynomialRoots(1, 1, -6))
+	assert.Empty(t, polynomialRoots(1, 0, 1))
+}
+
+func TestQuadraticRootsAvoidCancellation(t *testing.T) {
+	roots := polynomialRoots(1, 1e8, 1)
+
+	require.Len(t, roots, 2)
+	assert.InDelta(t, -1e-8, roots[1], 1e-20)
+}
+
+func TestCubicRoots(t *testing.T) {
+	// (x + 2)(x - 1)(x - 5)
+	assertRoots(t, []float64{-2, 1, 5}, polynomialRoots(1, -4, -7, 10))
+}
+
+func TestQuarticRoots(t *testing.T) {
+	// (x - 1)(x - 2)(x - 3)(x - 4)
+	assertRoots(t, []float64{1, 2, 3, 4}, polynomialRoots(1, -10, 35, -50, 24))
+	// (x² + 1)(x - 0.5)(x + 7)
+	assertRoots(t, []float64{-7, 0.5}, polynomialRoots(1, 6.5, -2.5, 6.5, -3.5))
+	assert.Empty(t, polynomialRoots(1, 0, 0, 0, 1))
+}
+
+func TestQuarticWithCloseRoots(t *testing.T) {
+	// (x - 1)(x - 1.000001)(x + 3)(x - 10)
+	a, b := 1.0, 1.000001
+	roots := polynomialRoots(1, -(a + b + 7), a*b+7*(a+b)-30, 30*(a+b)-7*a*b, -30*a*b)
+
+	assertRoots(t, []float64{-3, a, b, 10}, roots)
+}
+
+func TestLeadingZeroCoefficientsReduceDegree(t *testing.T) {
+	assertRoots(t, []float64{-3, 2}, polynomialRoots(0, 0, 1, 1, -6))
+	assert.Empty(t, polynomialRoots(0, 0, 5))
+}
+
+func TestMultiplyPolynomials(t *testing.T) {
+	// (x + 1)(2x - 3) = 2x² - x - 3
+	assert.Equal(t, []float64{2, -1, -3}, multiplyPolynomials([]float64{1, 1}, []float64{2, -3}))
+}
+
+func TestAddPolynomials(t *testing.T) {
+	assert.Equal(t, []float64{1, 3, 5}, addPolynomials([]float64{2, 3}, []float64{1, 1, 2}))
+	assert.Equal(t, []float64{1, 3, 5}, addPolynomials([]float64{1, 1, 2}, []float64{2, 3}))
+}
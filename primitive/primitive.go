@@ -1,9 +1,12 @@
 package primitive
 
 import (
+	"math"
+
 	"github.com/danieltmartin/ray-tracer/material"
 	"github.com/danieltmartin/ray-tracer/matrix"
 	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/transform"
 	"github.com/danieltmartin/ray-tracer/tuple"
 )
 
@@ -13,13 +16,26 @@ type Primitive interface {
 	InverseTransform() matrix.Matrix
 	SetMaterial(m material.Material)
 	SetTransform(t matrix.Matrix)
+	SetMotion(m transform.Motion)
 	Parent() *Group
 	NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple
+	// UVAt returns two-dimensional texture coordinates for a point on the surface, each
+	// usually between 0 and 1.
+	UVAt(worldPoint tuple.Tuple) (u, v float64)
 	Intersects(worldRay ray.Ray) Intersections
 	WorldPointToLocal(worldPoint tuple.Tuple) tuple.Tuple
 
 	Bounds() *BoundingBox
 	setParent(g *Group)
+	// inheritsMaterial reports whether Material comes from the parent group.
+	inheritsMaterial() bool
+	// keyTransforms returns transforms the primitive passes through, at its keys and at enough
+	// times between them that its bounds can cover all of its motion.
+	keyTransforms() []matrix.Matrix
+	moving() bool
+	// restPoint moves a point on the primitive at time to where it is when the primitive is
+	// still, in world space.
+	restPoint(worldPoint tuple.Tuple, time float64) tuple.Tuple
 }
 
 type data struct {
@@ -28,6 +44,8 @@ type data struct {
 	inverseTransform  matrix.Matrix
 	parent            *Group
 	useParentMaterial bool
+	// motion replaces transform when the primitive moves during the shutter interval.
+	motion *transform.Motion
 }
 
 func newData() data {
@@ -38,6 +56,7 @@ func newData() data {
 		ident,
 		nil,
 		true,
+		nil,
 	}
 }
 
@@ -59,6 +78,46 @@ func (d *data) InverseTransform() matrix.Matrix {
 func (d *data) SetTransform(m matrix.Matrix) {
 	d.transform = m
 	d.inverseTransform = m.Inverse()
+	d.motion = nil
+}
+
+// SetMotion makes the primitive move, transforming rays by the motion at their time. Transform
+// returns the transform of the first key, and points on the primitive are mapped to its local
+// space in that pose when looking up patterns.
+func (d *data) SetMotion(m transform.Motion) {
+	d.transform = m.Keys()[0].Matrix
+	d.inverseTransform = d.transform.Inverse()
+	d.motion = &m
+}
+
+func (d *data) inverseTransformAt(time float64) matrix.Matrix {
+	if d.motion == nil {
+		return d.inverseTransform
+	}
+	return d.motion.At(time).Inverse()
+}
+
+func (d *data) keyTransforms() []matrix.Matrix {
+	if d.motion == nil {
+		return []matrix.Matrix{d.transform}
+	}
+	keys := d.motion.Keys()
+	transforms := []matrix.Matrix{keys[0].Matrix}
+	for i := 1; i < len(keys); i++ {
+		start, end := keys[i-1].Time, keys[i].Time
+		for s := 1; s <= motionBoundsSteps; s++ {
+			transforms = append(transforms, d.motion.At(start+(end-start)*float64(s)/motionBoundsSteps))
+		}
+	}
+	return transforms
+}
+
+func (d *data) moving() bool {
+	return d.motion != nil || (d.parent != nil && d.parent.moving())
+}
+
+func (d *data) inheritsMaterial() bool {
+	return d.useParentMaterial
 }
 
 func (d *data) SetMaterial(m material.Material) {
@@ -73,18 +132,41 @@ func (d *data) WorldPointToLocal(world tuple.Tuple) tuple.Tuple {
 	return d.inverseTransform.MulTuple(world)
 }
 
+func (d *data) worldPointToLocalAt(world tuple.Tuple, time float64) tuple.Tuple {
+	if d.parent != nil {
+		world = d.parent.worldPointToLocalAt(world, time)
+	}
+	return d.inverseTransformAt(time).MulTuple(world)
+}
+
+func (d *data) localPointToWorld(local tuple.Tuple) tuple.Tuple {
+	world := d.transform.MulTuple(local)
+	if d.parent != nil {
+		world = d.parent.localPointToWorld(world)
+	}
+	return world
+}
+
+func (d *data) restPoint(worldPoint tuple.Tuple, time float64) tuple.Tuple {
+	return d.localPointToWorld(d.worldPointToLocalAt(worldPoint, time))
+}
+
 func (d *data) localNormalToWorld(localNormal tuple.Tuple) tuple.Tuple {
-	transformed := d.inverseTransform.Transpose().MulTuple(localNormal)
+	return d.localNormalToWorldAt(localNormal, 0)
+}
+
+func (d *data) localNormalToWorldAt(localNormal tuple.Tuple, time float64) tuple.Tuple {
+	transformed := d.inverseTransformAt(time).Transpose().MulTuple(localNormal)
 	worldNormal := tuple.New(transformed.X, transformed.Y, transformed.Z, 0).Norm()
 
 	if d.parent != nil {
-		worldNormal = d.parent.localNormalToWorld(worldNormal)
+		worldNormal = d.parent.localNormalToWorldAt(worldNormal, time)
 	}
 	return worldNormal
 }
 
 func (d *data) worldRayToLocal(r ray.Ray) ray.Ray {
-	return r.Transform(d.inverseTransform)
+	return r.Transform(d.inverseTransformAt(r.Time()))
 }
 
 type localIntersecter interface {
@@ -93,7 +175,13 @@ type localIntersecter interface {
 
 func (d *data) worldIntersects(worldRay ray.Ray, localIntersecter localIntersecter) Intersections {
 	localRay := d.worldRayToLocal(worldRay)
-	return localIntersecter.localIntersects(localRay)
+	xs := localIntersecter.localIntersects(localRay)
+	if time := worldRay.Time(); time != 0 {
+		for i := range xs {
+			xs[i].time = time
+		}
+	}
+	return xs
 }
 
 func (d *data) Parent() *Group {
@@ -109,7 +197,70 @@ type localNormalizer interface {
 }
 
 func (d *data) worldNormalAt(worldPoint tuple.Tuple, xn Intersection, localNormalizer localNormalizer) tuple.Tuple {
-	localPoint := d.WorldPointToLocal(worldPoint)
+	localPoint := d.worldPointToLocalAt(worldPoint, xn.time)
 	localNormal := localNormalizer.localNormalAt(localPoint, xn)
-	return d.localNormalToWorld(localNormal)
+	return d.localNormalToWorldAt(localNormal, xn.time)
+}
+
+// motionBoundsSteps is the number of poses between each pair of keys that the bounds of a
+// moving primitive cover.
+const motionBoundsSteps = 16
+
+// parentBounds returns the bounds of p in its parent's space. Rotations sweep arcs that bulge
+// past the poses sampled along them, so the bounds of a turning primitive are padded by as much
+// as a point could stray from them.
+func parentBounds(p Primitive) *BoundingBox {
+	transforms := p.keyTransforms()
+	if len(transforms) == 1 {
+		return p.Bounds().Transform(transforms[0])
+	}
+	box := NewEmptyBoundingBox()
+	for _, t := range transforms {
+		box.AddBox(p.Bounds().Transform(t))
+	}
+	// A point at distance r from the center of rotation, which is less than the size of the
+	// bounds, strays at most r(1-cos(turn/2)) from the chord between two samples.
+	size := box.max.Sub(box.min).Mag()
+	if turn := maxTurn(transforms); turn > 0 && !math.IsInf(size, 0) {
+		pad := size * (1 - math.Cos(turn/2))
+		box.min = box.min.Sub(tuple.NewVector(pad, pad, pad))
+		box.max = box.max.Add(tuple.NewVector(pad, pad, pad))
+	}
+	return box
+}
+
+// maxTurn returns the largest angle through which consecutive transforms rotate.
+func maxTurn(transforms []matrix.Matrix) float64 {
+	turn := 0.0
+	previous := transform.Decompose(transforms[0]).Rotation
+	for _, t := range transforms[1:] {
+		rotation := transform.Decompose(t).Rotation
+		turn = math.Max(turn, 2*math.Acos(math.Min(1, math.Abs(previous.Dot(rotation)))))
+		previous = rotation
+	}
+	return turn
+}
+
+// posed is a moving primitive at a moment in time.
+type posed struct {
+	Primitive
+	time float64
+}
+
+// AtTime returns p as it is at time, for looking up patterns and texture coordinates at points
+// on a moving primitive. A point on the moving surface maps to the same local point it would
+// have when the primitive is still, so patterns move with the surface.
+func AtTime(p Primitive, time float64) material.Object {
+	if !p.moving() {
+		return p
+	}
+	return posed{p, time}
+}
+
+func (p posed) WorldPointToLocal(worldPoint tuple.Tuple) tuple.Tuple {
+	return p.Primitive.WorldPointToLocal(p.restPoint(worldPoint, p.time))
+}
+
+func (p posed) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return p.Primitive.UVAt(p.restPoint(worldPoint, p.time))
 }
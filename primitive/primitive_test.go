@@ -4,6 +4,7 @@ import (
 	"math"
 	"testing"
 
+	"github.com/danieltmartin/ray-tracer/floatcolor"
 	"github.com/danieltmartin/ray-tracer/material"
 	"github.com/danieltmartin/ray-tracer/matrix"
 	"github.com/danieltmartin/ray-tracer/ray"
@@ -11,6 +12,7 @@ import (
 	"github.com/danieltmartin/ray-tracer/transform"
 	"github.com/danieltmartin/ray-tracer/tuple"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestPrimitiveDataDefaults(t *testing.T) {
@@ -110,3 +112,102 @@ func TestWorldNormalOnChildObject(t *testing.T) {
 
 	test.AssertAlmost(t, tuple.NewVector(0.2857, 0.4286, -0.8571), p)
 }
+
+func movingSphere() Sphere {
+	s := NewSphere()
+	s.SetMotion(transform.Between(transform.Translation(0, 0, 0), transform.Translation(4, 0, 0)))
+	return s
+}
+
+func TestIntersectMovingPrimitive(t *testing.T) {
+	s := movingSphere()
+	r := ray.New(tuple.NewPoint(2, 0, -5), tuple.NewVector(0, 0, 1))
+
+	assert.Empty(t, s.Intersects(r))
+	xs := s.Intersects(r.WithTime(0.5))
+	require.Len(t, xs, 2)
+	assert.Equal(t, 4.0, xs[0].distance)
+	assert.Equal(t, 0.5, xs[0].time)
+	assert.Equal(t, transform.Translation(0, 0, 0), s.Transform())
+}
+
+func TestNormalOnMovingPrimitive(t *testing.T) {
+	s := movingSphere()
+	g := NewGroup()
+	g.SetMotion(transform.Between(transform.Translation(0, 0, 0), transform.Translation(0, 2, 0)))
+	g.Add(&s)
+
+	xs := g.Intersects(ray.New(tuple.NewPoint(3, 1, -5), tuple.NewVector(0, 0, 1)).WithTime(0.5))
+	require.Len(t, xs, 2)
+	n := xs[0].object.NormalAt(tuple.NewPoint(2, 1, -1), xs[0])
+
+	assert.Equal(t, tuple.NewVector(0, 0, -1), n)
+}
+
+func TestSetTransformStopsMotion(t *testing.T) {
+	s := movingSphere()
+	s.SetTransform(transform.Translation(0, 5, 0))
+
+	assert.False(t, s.moving())
+	assert.Empty(t, s.Intersects(ray.New(tuple.NewPoint(2, 0, -5), tuple.NewVector(0, 0, 1)).WithTime(0.5)))
+}
+
+func TestGroupBoundsCoverMotion(t *testing.T) {
+	s := NewSphere()
+	s.SetMotion(transform.NewMotion(
+		transform.Key{Time: 0, Matrix: transform.Translation(0, 0, 0)},
+		transform.Key{Time: 0.5, Matrix: transform.Translation(0, 6, 0)},
+		transform.Key{Time: 1, Matrix: transform.Translation(4, 0, 0)},
+	))
+	g := NewGroup()
+	g.Add(&s)
+
+	assert.Equal(t, tuple.NewPoint(-1, -1, -1), g.Bounds().Min())
+	assert.Equal(t, tuple.NewPoint(5, 7, 1), g.Bounds().Max())
+}
+
+func TestGroupBoundsCoverRotationBetweenKeys(t *testing.T) {
+	s := NewSphere()
+	motion := transform.Between(
+		transform.Identity().Translation(5, 0, 0).Matrix(),
+		transform.Identity().Translation(5, 0, 0).RotationZ(math.Pi/2).Matrix(),
+	)
+	s.SetMotion(motion)
+	g := NewGroup()
+	g.Add(&s)
+
+	for _, time := range []float64{0.1, 0.25, 0.5, 0.77, 0.9} {
+		assert.True(t, g.Bounds().ContainsBox(s.Bounds().Transform(motion.At(time))), "time %v", time)
+	}
+}
+
+func TestPatternsMoveWithPrimitive(t *testing.T) {
+	s := movingSphere()
+	g := NewGroup()
+	g.Add(&s)
+	still := NewSphere()
+
+	assert.Same(t, &still, AtTime(&still, 0.5))
+	posed := AtTime(&s, 0.5)
+	assert.Equal(t, tuple.NewPoint(0, 0, -1), posed.WorldPointToLocal(tuple.NewPoint(2, 0, -1)))
+	u, v := posed.(material.UVMapper).UVAt(tuple.NewPoint(2, 0, -1))
+	expectedU, expectedV := sphericalUV(tuple.NewPoint(0, 0, -1))
+	assert.Equal(t, expectedU, u)
+	assert.Equal(t, expectedV, v)
+
+	m := material.Default.WithPattern(material.NewStripePattern(floatcolor.White, floatcolor.Black))
+	assert.Equal(t, floatcolor.White, m.ColorAt(posed, tuple.NewPoint(2.5, 0, -0.5)))
+	assert.Equal(t, floatcolor.Black, m.ColorAt(posed, tuple.NewPoint(3.5, 0, -0.5)))
+}
+
+func TestMovingInstance(t *testing.T) {
+	s := NewSphere()
+	i := NewInstance(&s)
+	i.SetMotion(transform.Between(transform.Translation(0, 0, 0), transform.Translation(0, 4, 0)))
+
+	xs := i.Intersects(ray.New(tuple.NewPoint(0, 2, -5), tuple.NewVector(0, 0, 1)).WithTime(0.5))
+
+	require.Len(t, xs, 2)
+	assert.Equal(t, tuple.NewVector(0, 0, -1), xs[0].object.NormalAt(tuple.NewPoint(0, 2, -1), xs[0]))
+	assert.Equal(t, tuple.NewPoint(0, 0, -1), AtTime(xs[0].object, 0.5).WorldPointToLocal(tuple.NewPoint(0, 2, -1)))
+}
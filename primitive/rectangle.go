@@ -0,0 +1,64 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Rectangle is a flat square on the x-z plane extending from -1 to 1 along both axes. Scale it
+// for other proportions.
+type Rectangle struct {
+	singleSided bool
+	data
+}
+
+func NewRectangle() Rectangle {
+	return Rectangle{false, newData()}
+}
+
+// SetSingleSided controls whether the rectangle can only be seen from above, where its normal
+// points. Rays approaching from below pass straight through a single-sided rectangle.
+func (re *Rectangle) SetSingleSided(singleSided bool) {
+	re.singleSided = singleSided
+}
+
+func (re *Rectangle) SingleSided() bool {
+	return re.singleSided
+}
+
+func (re *Rectangle) Intersects(worldRay ray.Ray) Intersections {
+	return re.worldIntersects(worldRay, re)
+}
+
+func (re *Rectangle) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
+	return re.worldNormalAt(worldPoint, xn, re)
+}
+
+func (re *Rectangle) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return re.worldUVAt(worldPoint, re)
+}
+
+func (re *Rectangle) localIntersects(localRay ray.Ray) Intersections {
+	distance, point, ok := xzPlaneIntersection(localRay, re.singleSided)
+	if !ok || math.Abs(point.X) > 1 || math.Abs(point.Z) > 1 {
+		return nil
+	}
+	return NewIntersections(NewIntersection(distance, re))
+}
+
+func (re *Rectangle) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple.Tuple {
+	return tuple.NewVector(0, 1, 0)
+}
+
+// localUVAt maps the rectangle onto the whole texture, with u increasing along x and v along -z.
+func (re *Rectangle) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	return discUV(localPoint)
+}
+
+var rectangleBounds = NewBoundingBox(tuple.NewPoint(-1, 0, -1), tuple.NewPoint(1, 0, 1))
+
+func (re *Rectangle) Bounds() *BoundingBox {
+	return rectangleBounds
+}
@@ -0,0 +1,82 @@
+package primitive
+
+import (
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRayIntersectingRectangle(t *testing.T) {
+	re := NewRectangle()
+
+	examples := []struct {
+		origin, direction tuple.Tuple
+		count             int
+	}{
+		{tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0), 1},
+		{tuple.NewPoint(0.9, -1, -0.9), tuple.NewVector(0, 1, 0), 1},
+		{tuple.NewPoint(1, 1, 1), tuple.NewVector(0, -1, 0), 1},
+		{tuple.NewPoint(1.1, 1, 0), tuple.NewVector(0, -1, 0), 0},
+		{tuple.NewPoint(0, 1, -1.1), tuple.NewVector(0, -1, 0), 0},
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1), 0},
+	}
+
+	for _, e := range examples {
+		r := ray.New(e.origin, e.direction)
+		xs := re.localIntersects(r)
+		assert.Len(t, xs, e.count)
+	}
+}
+
+func TestRayIntersectingScaledRectangle(t *testing.T) {
+	re := NewRectangle()
+	re.SetTransform(transform.Scaling(2, 1, 0.5))
+
+	hit := re.Intersects(ray.New(tuple.NewPoint(1.5, 3, 0.25), tuple.NewVector(0, -1, 0)))
+	miss := re.Intersects(ray.New(tuple.NewPoint(0, 3, 0.75), tuple.NewVector(0, -1, 0)))
+
+	require.Len(t, hit, 1)
+	assert.Equal(t, 3.0, hit[0].distance)
+	assert.Empty(t, miss)
+}
+
+func TestSingleSidedRectangleIsOnlyHitFromAbove(t *testing.T) {
+	re := NewRectangle()
+	re.SetSingleSided(true)
+
+	above := re.localIntersects(ray.New(tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0)))
+	below := re.localIntersects(ray.New(tuple.NewPoint(0, -1, 0), tuple.NewVector(0, 1, 0)))
+
+	assert.True(t, re.SingleSided())
+	assert.Len(t, above, 1)
+	assert.Empty(t, below)
+}
+
+func TestNormalOfRectangle(t *testing.T) {
+	re := NewRectangle()
+
+	n := re.localNormalAt(tuple.NewPoint(0.5, 0, -0.5), Intersection{})
+
+	assert.Equal(t, tuple.NewVector(0, 1, 0), n)
+}
+
+func TestRectangleUV(t *testing.T) {
+	re := NewRectangle()
+
+	assertUV(t, &re, tuple.NewPoint(-1, 0, 1), 0, 0)
+	assertUV(t, &re, tuple.NewPoint(1, 0, -1), 1, 1)
+	assertUV(t, &re, tuple.NewPoint(0.5, 0, 0), 0.75, 0.5)
+}
+
+func TestRectangleBounds(t *testing.T) {
+	re := NewRectangle()
+
+	b := re.Bounds()
+
+	assert.Equal(t, tuple.NewPoint(-1, 0, -1), b.min)
+	assert.Equal(t, tuple.NewPoint(1, 0, 1), b.max)
+}
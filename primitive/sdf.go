@@ -0,0 +1,129 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+const (
+	// sdfMinStep is the smallest distance a ray advances while sphere tracing, which bounds the
+	// time spent close to a surface and the thinnest feature that can be found.
+	sdfMinStep = 1e-4
+	// sdfMaxSteps limits the steps taken along a single ray.
+	sdfMaxSteps = 1000
+	// sdfRefineSteps is the number of bisection steps used to locate a surface once a ray has
+	// been found to cross it.
+	sdfRefineSteps = 40
+	// sdfNormalDelta is the distance between the points sampled to estimate normals.
+	sdfNormalDelta = 1e-4
+)
+
+// SDF is a shape defined by a signed distance function, intersected by sphere tracing within a
+// bounding box. It can represent shapes with no closed-form intersection, such as smoothly
+// blended solids and fractals.
+type SDF struct {
+	distance DistanceFunc
+	bounds   *BoundingBox
+	data
+}
+
+// NewSDF creates an SDF whose surface is where distance is zero, contained within bounds. The
+// distance function must not overestimate the distance to the surface, or rays may pass through
+// thin parts of the shape, and bounds must be finite.
+func NewSDF(distance DistanceFunc, bounds *BoundingBox) SDF {
+	if bounds == nil || isInfinite(bounds.min) || isInfinite(bounds.max) {
+		panic("sdf bounds must be finite")
+	}
+	return SDF{distance, bounds, newData()}
+}
+
+func isInfinite(p tuple.Tuple) bool {
+	return math.IsInf(p.X, 0) || math.IsInf(p.Y, 0) || math.IsInf(p.Z, 0)
+}
+
+func (s *SDF) Distance() DistanceFunc {
+	return s.distance
+}
+
+func (s *SDF) Intersects(worldRay ray.Ray) Intersections {
+	return s.worldIntersects(worldRay, s)
+}
+
+func (s *SDF) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
+	return s.worldNormalAt(worldPoint, xn, s)
+}
+
+func (s *SDF) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return s.worldUVAt(worldPoint, s)
+}
+
+// localIntersects sphere traces the whole of the ray within the bounding box, stepping by the
+// distance to the surface and bisecting wherever the sign of the distance changes, so that both
+// the entry and exit points of the shape are found.
+func (s *SDF) localIntersects(localRay ray.Ray) Intersections {
+	start, end, ok := s.bounds.intersectionRange(localRay)
+	if !ok {
+		return nil
+	}
+
+	// March along a unit direction so steps match distances, then convert back to distances
+	// along the original ray.
+	length := localRay.Direction().Mag()
+	direction := localRay.Direction().Mul(1 / length)
+	at := func(t float64) float64 {
+		return s.distance(localRay.Origin().Add(direction.Mul(t)))
+	}
+	start, end = start*length, end*length
+
+	var xs Intersections
+	t := start
+	d := at(t)
+	for i := 0; i < sdfMaxSteps && t < end; i++ {
+		next := math.Min(t+math.Max(math.Abs(d), sdfMinStep), end)
+		nextD := at(next)
+		if (d < 0) != (nextD < 0) {
+			xs = append(xs, NewIntersection(s.refine(at, t, next, d)/length, s))
+		}
+		t, d = next, nextD
+	}
+	return xs
+}
+
+// refine bisects between distances t0 and t1 along a ray, whose signed distances at t0 are of
+// the sign d0 and at t1 are the opposite, to find where the ray meets the surface.
+func (s *SDF) refine(at func(t float64) float64, t0, t1, d0 float64) float64 {
+	for i := 0; i < sdfRefineSteps; i++ {
+		mid := (t0 + t1) / 2
+		if (at(mid) < 0) == (d0 < 0) {
+			t0 = mid
+		} else {
+			t1 = mid
+		}
+	}
+	return (t0 + t1) / 2
+}
+
+// localNormalAt estimates the gradient of the distance function by central differences.
+func (s *SDF) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple.Tuple {
+	sample := func(dx, dy, dz float64) float64 {
+		return s.distance(localPoint.Add(tuple.NewVector(dx, dy, dz)))
+	}
+	h := sdfNormalDelta
+	return tuple.NewVector(
+		sample(h, 0, 0)-sample(-h, 0, 0),
+		sample(0, h, 0)-sample(0, -h, 0),
+		sample(0, 0, h)-sample(0, 0, -h),
+	).Norm()
+}
+
+// localUVAt has no natural mapping for an arbitrary shape, so it projects the surface onto a
+// sphere around the origin.
+func (s *SDF) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	return sphericalUV(localPoint)
+}
+
+func (s *SDF) Bounds() *BoundingBox {
+	return s.bounds
+}
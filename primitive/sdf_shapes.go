@@ -0,0 +1,153 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// DistanceFunc returns the signed distance from a point to the surface of a shape, negative
+// inside the shape and positive outside.
+type DistanceFunc func(p tuple.Tuple) float64
+
+// SphereDistance is the distance function of a sphere of radius centered on the origin.
+func SphereDistance(radius float64) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		return math.Sqrt(p.X*p.X+p.Y*p.Y+p.Z*p.Z) - radius
+	}
+}
+
+// BoxDistance is the distance function of a box centered on the origin, extending x, y and z
+// units from the origin along each axis.
+func BoxDistance(x, y, z float64) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		qx, qy, qz := math.Abs(p.X)-x, math.Abs(p.Y)-y, math.Abs(p.Z)-z
+		ox, oy, oz := math.Max(qx, 0), math.Max(qy, 0), math.Max(qz, 0)
+		outside := math.Sqrt(ox*ox + oy*oy + oz*oz)
+		inside := math.Min(max(qx, qy, qz), 0)
+		return outside + inside
+	}
+}
+
+// TorusDistance is the distance function of a torus around the y axis, like NewTorus.
+func TorusDistance(majorRadius, minorRadius float64) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		q := math.Sqrt(p.X*p.X+p.Z*p.Z) - majorRadius
+		return math.Sqrt(q*q+p.Y*p.Y) - minorRadius
+	}
+}
+
+// CapsuleDistance is the distance function of a cylinder of radius with hemispherical ends,
+// whose axis runs from point a to point b.
+func CapsuleDistance(a, b tuple.Tuple, radius float64) DistanceFunc {
+	ab := b.Sub(a)
+	return func(p tuple.Tuple) float64 {
+		ap := p.Sub(a)
+		h := math.Max(0, math.Min(1, ap.Dot(ab)/ab.Dot(ab)))
+		return ap.Sub(ab.Mul(h)).Mag() - radius
+	}
+}
+
+// MandelbulbDistance estimates the distance to the Mandelbulb fractal of the given power, using
+// up to iterations steps of its formula. The fractal fits within a sphere of radius 1.2 for the
+// usual power of 8.
+func MandelbulbDistance(power float64, iterations int) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		x, y, z := p.X, p.Y, p.Z
+		dr, r := 1.0, 0.0
+		for i := 0; i < iterations; i++ {
+			r = math.Sqrt(x*x + y*y + z*z)
+			if r > 2 || r == 0 {
+				break
+			}
+			theta := math.Acos(y/r) * power
+			phi := math.Atan2(z, x) * power
+			dr = math.Pow(r, power-1)*power*dr + 1
+			zr := math.Pow(r, power)
+			x = zr*math.Sin(theta)*math.Cos(phi) + p.X
+			y = zr*math.Cos(theta) + p.Y
+			z = zr*math.Sin(theta)*math.Sin(phi) + p.Z
+		}
+		if r == 0 {
+			return 0
+		}
+		return 0.5 * math.Log(r) * r / dr
+	}
+}
+
+// Union is the shape covered by either f or g.
+func (f DistanceFunc) Union(g DistanceFunc) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		return math.Min(f(p), g(p))
+	}
+}
+
+// Intersect is the shape covered by both f and g.
+func (f DistanceFunc) Intersect(g DistanceFunc) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		return math.Max(f(p), g(p))
+	}
+}
+
+// Subtract is the shape covered by f but not g.
+func (f DistanceFunc) Subtract(g DistanceFunc) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		return math.Max(f(p), -g(p))
+	}
+}
+
+// SmoothUnion joins f and g like Union, filling in the crease where they meet so that they
+// flow into each other over a distance of about k.
+func (f DistanceFunc) SmoothUnion(g DistanceFunc, k float64) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		return smoothMin(f(p), g(p), k)
+	}
+}
+
+// SmoothIntersect is like Intersect, rounding the edge where f and g meet over a distance of
+// about k.
+func (f DistanceFunc) SmoothIntersect(g DistanceFunc, k float64) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		return -smoothMin(-f(p), -g(p), k)
+	}
+}
+
+// SmoothSubtract is like Subtract, rounding the edge of the hole cut by g over a distance of
+// about k.
+func (f DistanceFunc) SmoothSubtract(g DistanceFunc, k float64) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		return -smoothMin(-f(p), g(p), k)
+	}
+}
+
+// Blend morphs between f, when weight is 0, and g, when weight is 1.
+func (f DistanceFunc) Blend(g DistanceFunc, weight float64) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		return f(p)*(1-weight) + g(p)*weight
+	}
+}
+
+// Translate moves the shape by x, y and z.
+func (f DistanceFunc) Translate(x, y, z float64) DistanceFunc {
+	offset := tuple.NewVector(x, y, z)
+	return func(p tuple.Tuple) float64 {
+		return f(p.Sub(offset))
+	}
+}
+
+// Scale resizes the shape uniformly by factor about the origin.
+func (f DistanceFunc) Scale(factor float64) DistanceFunc {
+	return func(p tuple.Tuple) float64 {
+		return f(tuple.NewPoint(p.X/factor, p.Y/factor, p.Z/factor)) * factor
+	}
+}
+
+// smoothMin is a polynomial approximation of the minimum of a and b that blends smoothly
+// between them where they are within k of each other.
+func smoothMin(a, b, k float64) float64 {
+	if k <= 0 {
+		return math.Min(a, b)
+	}
+	h := math.Max(0, math.Min(1, 0.5+0.5*(b-a)/k))
+	return b + (a-b)*h - k*h*(1-h)
+}
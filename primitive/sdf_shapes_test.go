@@ -0,0 +1,81 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+func TestBuiltInDistanceFunctions(t *testing.T) {
+	examples := []struct {
+		name     string
+		distance DistanceFunc
+		point    tuple.Tuple
+		expected float64
+	}{
+		{"sphere outside", SphereDistance(1), tuple.NewPoint(0, 3, 0), 2},
+		{"sphere inside", SphereDistance(2), tuple.NewPoint(0.5, 0, 0), -1.5},
+		{"box face", BoxDistance(1, 2, 3), tuple.NewPoint(0, 0, 4), 1},
+		{"box corner", BoxDistance(1, 1, 1), tuple.NewPoint(2, 2, 1), math.Sqrt2},
+		{"box inside", BoxDistance(1, 2, 3), tuple.NewPoint(0.5, 0, 0), -0.5},
+		{"torus tube", TorusDistance(2, 0.5), tuple.NewPoint(0, 1, 2), 0.5},
+		{"torus hole", TorusDistance(2, 0.5), tuple.NewPoint(0, 0, 0), 1.5},
+		{"capsule side", CapsuleDistance(tuple.NewPoint(0, 0, 0), tuple.NewPoint(0, 2, 0), 0.5), tuple.NewPoint(1, 1, 0), 0.5},
+		{"capsule end", CapsuleDistance(tuple.NewPoint(0, 0, 0), tuple.NewPoint(0, 2, 0), 0.5), tuple.NewPoint(0, 4, 0), 1.5},
+		{"mandelbulb center", MandelbulbDistance(8, 10), tuple.NewPoint(0, 0, 0), 0},
+	}
+
+	for _, e := range examples {
+		t.Run(e.name, func(t *testing.T) {
+			test.AssertAlmost(t, e.expected, e.distance(e.point))
+		})
+	}
+}
+
+func TestMandelbulbDistanceIsPositiveOutside(t *testing.T) {
+	d := MandelbulbDistance(8, 10)
+
+	outside := d(tuple.NewPoint(0, 0, 2))
+
+	if outside <= 0 || outside > 2 {
+		t.Errorf("expected distance between 0 and 2, got %v", outside)
+	}
+}
+
+func TestDistanceCombinators(t *testing.T) {
+	a := SphereDistance(1)
+	b := SphereDistance(1).Translate(1, 0, 0)
+	p := tuple.NewPoint(0.5, 0, 0)
+	q := tuple.NewPoint(-0.5, 0, 0)
+
+	test.AssertAlmost(t, -0.5, a.Union(b)(q))
+	test.AssertAlmost(t, -0.5, a.Union(b)(tuple.NewPoint(1.5, 0, 0)))
+	test.AssertAlmost(t, -0.5, a.Intersect(b)(p))
+	test.AssertAlmost(t, 0.5, a.Intersect(b)(q))
+	test.AssertAlmost(t, 0.5, a.Subtract(b)(p))
+	test.AssertAlmost(t, -0.5, a.Subtract(b)(q))
+	test.AssertAlmost(t, 0.0, a.Blend(b, 0.5)(q))
+	test.AssertAlmost(t, -0.25, a.Blend(b, 0.25)(q))
+	test.AssertAlmost(t, 1.0, a.Scale(2)(tuple.NewPoint(0, 3, 0)))
+}
+
+func TestSmoothCombinatorsMatchHardOnesFarApart(t *testing.T) {
+	a := SphereDistance(1)
+	b := SphereDistance(1).Translate(5, 0, 0)
+	p := tuple.NewPoint(-2, 0, 0)
+
+	test.AssertAlmost(t, a.Union(b)(p), a.SmoothUnion(b, 0.5)(p))
+	test.AssertAlmost(t, a.Intersect(b)(p), a.SmoothIntersect(b, 0.5)(p))
+	test.AssertAlmost(t, a.Subtract(b)(p), a.SmoothSubtract(b, 0.5)(p))
+}
+
+func TestSmoothUnionIsBelowUnionWhereShapesMeet(t *testing.T) {
+	a := SphereDistance(1)
+	b := SphereDistance(1).Translate(2, 0, 0)
+	p := tuple.NewPoint(1, 0.5, 0)
+
+	test.AssertAlmost(t, a.Union(b)(p)-0.125, a.SmoothUnion(b, 0.5)(p))
+	test.AssertAlmost(t, a.Union(b)(p), a.SmoothUnion(b, 0)(p))
+}
@@ -0,0 +1,123 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func unitBounds(size float64) *BoundingBox {
+	return NewBoundingBox(tuple.NewPoint(-size, -size, -size), tuple.NewPoint(size, size, size))
+}
+
+func TestSDFSphereMatchesSphere(t *testing.T) {
+	s := NewSDF(SphereDistance(1), unitBounds(1))
+
+	examples := []struct {
+		origin, direction tuple.Tuple
+		ts                []float64
+	}{
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1), []float64{4, 6}},
+		{tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, 1), []float64{-1, 1}},
+		{tuple.NewPoint(0, 0, 5), tuple.NewVector(0, 0, 1), []float64{}},
+		{tuple.NewPoint(0.5, 0.5, -5), tuple.NewVector(0, 0, 2), []float64{2.14645, 2.85355}},
+		{tuple.NewPoint(0, 2, -5), tuple.NewVector(0, 0, 1), []float64{}},
+	}
+
+	for _, e := range examples {
+		r := ray.New(e.origin, e.direction)
+		xs := s.localIntersects(r)
+		require.Len(t, xs, len(e.ts))
+		for i := range e.ts {
+			test.AssertAlmost(t, e.ts[i], xs[i].distance)
+		}
+	}
+}
+
+func TestSDFTangentRayMissesThinnerThanMinStep(t *testing.T) {
+	s := NewSDF(SphereDistance(1), unitBounds(1))
+	r := ray.New(tuple.NewPoint(0, 1+sdfMinStep, -5), tuple.NewVector(0, 0, 1))
+
+	xs := s.localIntersects(r)
+
+	assert.Empty(t, xs)
+}
+
+func TestSDFFindsBothSidesOfHole(t *testing.T) {
+	d := BoxDistance(1, 1, 1).Subtract(SphereDistance(0.5))
+	s := NewSDF(d, unitBounds(1))
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	xs := s.localIntersects(r)
+
+	require.Len(t, xs, 4)
+	test.AssertAlmost(t, 4.0, xs[0].distance)
+	test.AssertAlmost(t, 4.5, xs[1].distance)
+	test.AssertAlmost(t, 5.5, xs[2].distance)
+	test.AssertAlmost(t, 6.0, xs[3].distance)
+}
+
+func TestSDFSmoothUnionFillsGap(t *testing.T) {
+	left := SphereDistance(0.5).Translate(-0.55, 0, 0)
+	right := SphereDistance(0.5).Translate(0.55, 0, 0)
+	r := ray.New(tuple.NewPoint(0, 5, 0), tuple.NewVector(0, -1, 0))
+
+	hard := NewSDF(left.Union(right), unitBounds(1.5))
+	smooth := NewSDF(left.SmoothUnion(right, 0.5), unitBounds(1.5))
+
+	assert.Empty(t, hard.localIntersects(r))
+	assert.Len(t, smooth.localIntersects(r), 2)
+}
+
+func TestNormalOnSDF(t *testing.T) {
+	s := NewSDF(SphereDistance(1), unitBounds(1))
+	r := math.Sqrt(3) / 3
+
+	examples := []struct {
+		point, normal tuple.Tuple
+	}{
+		{tuple.NewPoint(1, 0, 0), tuple.NewVector(1, 0, 0)},
+		{tuple.NewPoint(0, -1, 0), tuple.NewVector(0, -1, 0)},
+		{tuple.NewPoint(r, r, r), tuple.NewVector(r, r, r)},
+	}
+
+	for _, e := range examples {
+		n := s.localNormalAt(e.point, Intersection{})
+		test.AssertAlmost(t, e.normal, n)
+	}
+}
+
+func TestIntersectingTransformedSDF(t *testing.T) {
+	s := NewSDF(TorusDistance(1, 0.25), unitBounds(1.25))
+	s.SetTransform(transform.Translation(0, 0, 10).Mul(transform.Scaling(2, 2, 2)))
+	r := ray.New(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, 1))
+
+	xs := s.Intersects(r)
+
+	require.Len(t, xs, 4)
+	test.AssertAlmost(t, 7.5, xs[0].distance)
+	test.AssertAlmost(t, 8.5, xs[1].distance)
+	test.AssertAlmost(t, 11.5, xs[2].distance)
+	test.AssertAlmost(t, 12.5, xs[3].distance)
+	test.AssertAlmost(t, tuple.NewVector(0, 0, -1), s.NormalAt(r.Position(7.5), xs[0]))
+}
+
+func TestSDFBounds(t *testing.T) {
+	s := NewSDF(SphereDistance(2), unitBounds(2))
+
+	b := s.Bounds()
+
+	assert.Equal(t, tuple.NewPoint(-2, -2, -2), b.min)
+	assert.Equal(t, tuple.NewPoint(2, 2, 2), b.max)
+}
+
+func TestSDFPanicsWithoutFiniteBounds(t *testing.T) {
+	assert.Panics(t, func() { NewSDF(SphereDistance(1), nil) })
+	assert.Panics(t, func() { NewSDF(SphereDistance(1), NewEmptyBoundingBox()) })
+}
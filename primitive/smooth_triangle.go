@@ -34,10 +34,18 @@ func (t *SmoothTriangle) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple
 	return t.worldNormalAt(worldPoint, xn, t)
 }
 
+func (t *SmoothTriangle) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return t.worldUVAt(worldPoint, t)
+}
+
 func (t *SmoothTriangle) localNormalAt(localPoint tuple.Tuple, xn Intersection) tuple.Tuple {
 	return t.n2.Mul(xn.u).Add(t.n3.Mul(xn.v).Add(t.n1.Mul(1 - xn.u - xn.v)))
 }
 
+func (t *SmoothTriangle) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	return barycentricUV(localPoint, t.p1, t.e1, t.e2)
+}
+
 func (t *SmoothTriangle) localIntersects(localRay ray.Ray) Intersections {
 	return triangleIntersects(t, localRay, t.p1, t.p2, t.e1, t.e2)
 }
@@ -25,6 +25,10 @@ func (s *Sphere) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
 	return s.worldNormalAt(worldPoint, xn, s)
 }
 
+func (s *Sphere) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return s.worldUVAt(worldPoint, s)
+}
+
 func (s *Sphere) localIntersects(localRay ray.Ray) Intersections {
 	sphereToRay := localRay.Origin().Sub(tuple.NewPoint(0, 0, 0))
 
@@ -48,6 +52,10 @@ func (s *Sphere) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple.Tup
 	return localPoint.Sub(tuple.NewPoint(0, 0, 0))
 }
 
+func (s *Sphere) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	return sphericalUV(localPoint)
+}
+
 var sphereBounds = NewBoundingBox(
 	tuple.NewPoint(-1, -1, -1),
 	tuple.NewPoint(1, 1, 1),
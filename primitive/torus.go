@@ -0,0 +1,101 @@
+package primitive
+
+import (
+	"math"
+	"sort"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Torus is a ring centered on the origin around the y axis, formed by sweeping a circle of the
+// minor radius around a circle of the major radius.
+type Torus struct {
+	majorRadius, minorRadius float64
+	bounds                   *BoundingBox
+	data
+}
+
+func NewTorus(majorRadius, minorRadius float64) Torus {
+	if majorRadius <= 0 || minorRadius <= 0 {
+		panic("torus radii must be positive")
+	}
+	outer := majorRadius + minorRadius
+	bounds := NewBoundingBox(
+		tuple.NewPoint(-outer, -minorRadius, -outer),
+		tuple.NewPoint(outer, minorRadius, outer))
+	return Torus{majorRadius, minorRadius, bounds, newData()}
+}
+
+func (to *Torus) Radii() (major, minor float64) {
+	return to.majorRadius, to.minorRadius
+}
+
+func (to *Torus) Intersects(worldRay ray.Ray) Intersections {
+	return to.worldIntersects(worldRay, to)
+}
+
+func (to *Torus) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple {
+	return to.worldNormalAt(worldPoint, xn, to)
+}
+
+func (to *Torus) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return to.worldUVAt(worldPoint, to)
+}
+
+func (to *Torus) localIntersects(localRay ray.Ray) Intersections {
+	if !to.bounds.intersects(localRay) {
+		return nil
+	}
+
+	// Solve with a unit direction, starting from the point on the ray closest to the center to
+	// keep the coefficients small, then convert back to distances along the original ray.
+	length := localRay.Direction().Mag()
+	d := localRay.Direction().Mul(1 / length)
+	start := -localRay.Origin().Sub(tuple.NewPoint(0, 0, 0)).Dot(d)
+	o := localRay.Origin().Add(d.Mul(start)).Sub(tuple.NewPoint(0, 0, 0))
+
+	major2 := to.majorRadius * to.majorRadius
+	minor2 := to.minorRadius * to.minorRadius
+	e := o.Dot(o) - major2 - minor2
+	f := o.Dot(d)
+	roots := polynomialRoots(
+		1,
+		4*f,
+		2*e+4*f*f+4*major2*d.Y*d.Y,
+		4*f*e+8*major2*o.Y*d.Y,
+		e*e-4*major2*(minor2-o.Y*o.Y),
+	)
+	if len(roots) == 0 {
+		return nil
+	}
+
+	xs := make(Intersections, 0, len(roots))
+	for _, r := range roots {
+		xs = append(xs, NewIntersection((start+r)/length, to))
+	}
+	sort.Slice(xs, func(i, j int) bool { return xs[i].distance < xs[j].distance })
+	return xs
+}
+
+func (to *Torus) localNormalAt(localPoint tuple.Tuple, _ Intersection) tuple.Tuple {
+	// Gradient of the torus's implicit surface equation.
+	x, y, z, _ := localPoint.XYZW()
+	major2 := to.majorRadius * to.majorRadius
+	k := x*x + y*y + z*z - major2 - to.minorRadius*to.minorRadius
+	return tuple.NewVector(x*k, y*(k+2*major2), z*k)
+}
+
+// localUVAt maps u around the y axis as for a cylinder, and v around the tube, starting at its
+// inside edge and passing over the top.
+func (to *Torus) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	u, _ = cylindricalUV(localPoint)
+	distance := math.Sqrt(localPoint.X*localPoint.X+localPoint.Z*localPoint.Z) - to.majorRadius
+	phi := math.Atan2(localPoint.Y, distance)
+	v = 0.5 - phi/(2*math.Pi)
+	return u, v
+}
+
+func (to *Torus) Bounds() *BoundingBox {
+	return to.bounds
+}
@@ -0,0 +1,114 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRayMissesTorus(t *testing.T) {
+	to := NewTorus(1, 0.25)
+
+	examples := []struct {
+		origin, direction tuple.Tuple
+	}{
+		{tuple.NewPoint(0, 5, 0), tuple.NewVector(0, -1, 0)},
+		{tuple.NewPoint(0, 1, -5), tuple.NewVector(0, 0, 1)},
+		{tuple.NewPoint(2, 0, -5), tuple.NewVector(0, 0, 1)},
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(1, 0, 0)},
+	}
+
+	for _, e := range examples {
+		r := ray.New(e.origin, e.direction)
+		xs := to.localIntersects(r)
+		assert.Empty(t, xs)
+	}
+}
+
+func TestRayHitsTorus(t *testing.T) {
+	to := NewTorus(1, 0.25)
+
+	examples := []struct {
+		origin, direction tuple.Tuple
+		ts                []float64
+	}{
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1), []float64{3.75, 4.25, 5.75, 6.25}},
+		{tuple.NewPoint(1, 5, 0), tuple.NewVector(0, -1, 0), []float64{4.75, 5.25}},
+		{tuple.NewPoint(1, 0, 0), tuple.NewVector(1, 0, 0), []float64{-2.25, -1.75, -0.25, 0.25}},
+		{tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 2), []float64{1.875, 2.125, 2.875, 3.125}},
+		{tuple.NewPoint(-5, 0.1, -5), tuple.NewVector(1, 0, 1), []float64{4.13087, 4.45491, 5.54509, 5.86913}},
+	}
+
+	for _, e := range examples {
+		r := ray.New(e.origin, e.direction)
+		xs := to.localIntersects(r)
+		require.Len(t, xs, len(e.ts))
+		for i := range e.ts {
+			test.AssertAlmost(t, e.ts[i], xs[i].distance)
+		}
+	}
+}
+
+func TestRayHitsTransformedTorus(t *testing.T) {
+	to := NewTorus(1, 0.25)
+	to.SetTransform(transform.RotationX(math.Pi / 2))
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	assert.Empty(t, to.Intersects(r))
+
+	r = ray.New(tuple.NewPoint(0, 5, 0), tuple.NewVector(0, -1, 0))
+	xs := to.Intersects(r)
+
+	require.Len(t, xs, 4)
+	test.AssertAlmost(t, 3.75, xs[0].distance)
+}
+
+func TestNormalVectorOnTorus(t *testing.T) {
+	to := NewTorus(1, 0.25)
+	d := 0.25 * math.Sqrt2 / 2
+
+	examples := []struct {
+		point, normal tuple.Tuple
+	}{
+		{tuple.NewPoint(1.25, 0, 0), tuple.NewVector(1, 0, 0)},
+		{tuple.NewPoint(0.75, 0, 0), tuple.NewVector(-1, 0, 0)},
+		{tuple.NewPoint(1, 0.25, 0), tuple.NewVector(0, 1, 0)},
+		{tuple.NewPoint(0, -0.25, -1), tuple.NewVector(0, -1, 0)},
+		{tuple.NewPoint(0, 0, -1.25), tuple.NewVector(0, 0, -1)},
+		{tuple.NewPoint(1+d, d, 0), tuple.NewVector(1, 1, 0).Norm()},
+	}
+
+	for _, e := range examples {
+		n := to.localNormalAt(e.point, Intersection{})
+		test.AssertAlmost(t, e.normal, n.Norm())
+	}
+}
+
+func TestTorusUV(t *testing.T) {
+	to := NewTorus(1, 0.25)
+
+	assertUV(t, &to, tuple.NewPoint(0.75, 0, 0), 0.25, 0)
+	assertUV(t, &to, tuple.NewPoint(1, 0.25, 0), 0.25, 0.25)
+	assertUV(t, &to, tuple.NewPoint(0, 0, -1.25), 0, 0.5)
+	assertUV(t, &to, tuple.NewPoint(-1, -0.25, 0), 0.75, 0.75)
+}
+
+func TestTorusBounds(t *testing.T) {
+	to := NewTorus(2, 0.5)
+
+	b := to.Bounds()
+
+	assert.Equal(t, tuple.NewPoint(-2.5, -0.5, -2.5), b.Min())
+	assert.Equal(t, tuple.NewPoint(2.5, 0.5, 2.5), b.Max())
+}
+
+func TestTorusPanicsWithoutPositiveRadii(t *testing.T) {
+	assert.Panics(t, func() { NewTorus(0, 1) })
+	assert.Panics(t, func() { NewTorus(1, -1) })
+}
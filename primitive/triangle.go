@@ -32,10 +32,18 @@ func (t *Triangle) NormalAt(worldPoint tuple.Tuple, xn Intersection) tuple.Tuple
 	return t.worldNormalAt(worldPoint, xn, t)
 }
 
+func (t *Triangle) UVAt(worldPoint tuple.Tuple) (u, v float64) {
+	return t.worldUVAt(worldPoint, t)
+}
+
 func (t *Triangle) localNormalAt(_ tuple.Tuple, _ Intersection) tuple.Tuple {
 	return t.normal
 }
 
+func (t *Triangle) localUVAt(localPoint tuple.Tuple) (u, v float64) {
+	return barycentricUV(localPoint, t.p1, t.e1, t.e2)
+}
+
 func (t *Triangle) localIntersects(localRay ray.Ray) Intersections {
 	return triangleIntersects(t, localRay, t.p1, t.p2, t.e1, t.e2)
 }
@@ -78,3 +86,30 @@ func triangleIntersects(triangle Primitive, localRay ray.Ray, p1, p2, e1, e2 tup
 	d := f * e2.Dot(originCrossE1)
 	return NewIntersections(NewIntersectionWithUV(d, u, v, triangle))
 }
+
+// triangleHit finds where a ray hits the triangle with vertex p1 and edges e1 and e2, returning
+// the distance and the weights u and v of the second and third vertices. Unlike
+// triangleIntersects, it has no fixed tolerance, so it works for the tiny triangles of dense
+// meshes and grids.
+func triangleHit(localRay ray.Ray, p1, e1, e2 tuple.Tuple) (d, u, v float64, ok bool) {
+	dirCrossE2 := localRay.Direction().Cross(e2)
+	det := e1.Dot(dirCrossE2)
+	if det == 0 {
+		return 0, 0, 0, false
+	}
+
+	f := 1 / det
+	p1ToOrigin := localRay.Origin().Sub(p1)
+	u = f * p1ToOrigin.Dot(dirCrossE2)
+	if u < 0 || u > 1 {
+		return 0, 0, 0, false
+	}
+
+	originCrossE1 := p1ToOrigin.Cross(e1)
+	v = f * localRay.Direction().Dot(originCrossE1)
+	if v < 0 || u+v > 1 {
+		return 0, 0, 0, false
+	}
+
+	return f * e2.Dot(originCrossE1), u, v, true
+}
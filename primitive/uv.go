@@ -0,0 +1,62 @@
+package primitive
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+type localUVMapper interface {
+	localUVAt(localPoint tuple.Tuple) (u, v float64)
+}
+
+func (d *data) worldUVAt(worldPoint tuple.Tuple, localUVMapper localUVMapper) (u, v float64) {
+	return localUVMapper.localUVAt(d.WorldPointToLocal(worldPoint))
+}
+
+// sphericalUV maps a point to longitude u, increasing anticlockwise around the y axis viewed
+// from above and starting at -z, and latitude v, from 0 at the south pole to 1 at the north.
+func sphericalUV(p tuple.Tuple) (u, v float64) {
+	theta := math.Atan2(p.X, p.Z)
+	r := p.Sub(tuple.NewPoint(0, 0, 0)).Mag()
+	if r == 0 {
+		return 0.5, 0.5
+	}
+	phi := math.Acos(math.Max(-1, math.Min(1, p.Y/r)))
+	u = 1 - (theta/(2*math.Pi) + 0.5)
+	v = 1 - phi/math.Pi
+	return u, v
+}
+
+// cylindricalUV maps a point to u around the y axis like sphericalUV, and v repeating every unit
+// along the y axis.
+func cylindricalUV(p tuple.Tuple) (u, v float64) {
+	theta := math.Atan2(p.X, p.Z)
+	u = 1 - (theta/(2*math.Pi) + 0.5)
+	v = p.Y - math.Floor(p.Y)
+	return u, v
+}
+
+// planarUV maps a point on the x-z plane to coordinates repeating every unit along x and z.
+func planarUV(p tuple.Tuple) (u, v float64) {
+	return p.X - math.Floor(p.X), p.Z - math.Floor(p.Z)
+}
+
+// discUV maps a point on the x-z plane between -1 and 1 on each axis, such as within the unit
+// circle, to coordinates from 0 to 1.
+func discUV(p tuple.Tuple) (u, v float64) {
+	return (p.X + 1) / 2, (1 - p.Z) / 2
+}
+
+// barycentricUV returns the weights of the second and third vertices of the triangle with
+// vertex p1 and edges e1 and e2 at p, matching the u and v of intersections with it.
+func barycentricUV(p, p1, e1, e2 tuple.Tuple) (u, v float64) {
+	w := p.Sub(p1)
+	d00, d01, d11 := e1.Dot(e1), e1.Dot(e2), e2.Dot(e2)
+	d20, d21 := w.Dot(e1), w.Dot(e2)
+	denom := d00*d11 - d01*d01
+	if denom == 0 {
+		return 0, 0
+	}
+	return (d11*d20 - d01*d21) / denom, (d00*d21 - d01*d20) / denom
+}
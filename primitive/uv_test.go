@@ -0,0 +1,82 @@
+package primitive
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/float"
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func assertUV(t *testing.T, p Primitive, point tuple.Tuple, expectedU, expectedV float64) {
+	t.Helper()
+	u, v := p.UVAt(point)
+	assert.InDelta(t, expectedU, u, float.Epsilon, "u at %v", point)
+	assert.InDelta(t, expectedV, v, float.Epsilon, "v at %v", point)
+}
+
+func TestSphereUV(t *testing.T) {
+	s := NewSphere()
+	r := math.Sqrt(2) / 2
+
+	assertUV(t, &s, tuple.NewPoint(0, 0, -1), 0, 0.5)
+	assertUV(t, &s, tuple.NewPoint(1, 0, 0), 0.25, 0.5)
+	assertUV(t, &s, tuple.NewPoint(0, 0, 1), 0.5, 0.5)
+	assertUV(t, &s, tuple.NewPoint(-1, 0, 0), 0.75, 0.5)
+	assertUV(t, &s, tuple.NewPoint(0, 1, 0), 0.5, 1)
+	assertUV(t, &s, tuple.NewPoint(0, -1, 0), 0.5, 0)
+	assertUV(t, &s, tuple.NewPoint(r, r, 0), 0.25, 0.75)
+}
+
+func TestSphereUVUsesObjectSpace(t *testing.T) {
+	s := NewSphere()
+	s.SetTransform(transform.Translation(5, 0, 0).Mul(transform.Scaling(2, 2, 2)))
+
+	assertUV(t, &s, tuple.NewPoint(7, 0, 0), 0.25, 0.5)
+}
+
+func TestPlaneUV(t *testing.T) {
+	p := NewPlane()
+
+	assertUV(t, &p, tuple.NewPoint(0.25, 0, 0.5), 0.25, 0.5)
+	assertUV(t, &p, tuple.NewPoint(1.25, 0, -0.25), 0.25, 0.75)
+	assertUV(t, &p, tuple.NewPoint(-0.25, 0, 3.5), 0.75, 0.5)
+}
+
+func TestCylinderUV(t *testing.T) {
+	c := NewCylinder(0, 2, true)
+
+	assertUV(t, &c, tuple.NewPoint(0, 0.5, -1), 0, 0.5)
+	assertUV(t, &c, tuple.NewPoint(1, 1.25, 0), 0.25, 0.25)
+	assertUV(t, &c, tuple.NewPoint(0, 2, 0), 0.5, 0.5)
+	assertUV(t, &c, tuple.NewPoint(0.5, 2, -0.5), 0.75, 0.75)
+}
+
+func TestConeCapUV(t *testing.T) {
+	c := NewCone(0, 2, true)
+
+	assertUV(t, &c, tuple.NewPoint(0, 2, -1), 0.5, 0.75)
+	assertUV(t, &c, tuple.NewPoint(1.5, 2, 0), 0.875, 0.5)
+	assertUV(t, &c, tuple.NewPoint(0, 2, 0), 0.5, 0.5)
+}
+
+func TestCubeUV(t *testing.T) {
+	c := NewCube()
+
+	assertUV(t, &c, tuple.NewPoint(-0.5, 0.5, 1), 0.25, 0.75)
+	assertUV(t, &c, tuple.NewPoint(0.5, -0.5, -1), 0.25, 0.25)
+	assertUV(t, &c, tuple.NewPoint(1, 0.5, -0.5), 0.75, 0.75)
+	assertUV(t, &c, tuple.NewPoint(-1, -0.5, 0.5), 0.75, 0.25)
+	assertUV(t, &c, tuple.NewPoint(-0.5, 1, -0.5), 0.25, 0.75)
+	assertUV(t, &c, tuple.NewPoint(-0.5, -1, 0.5), 0.25, 0.75)
+}
+
+func TestTriangleUVMatchesIntersection(t *testing.T) {
+	tr := NewTriangle(tuple.NewPoint(0, 1, 0), tuple.NewPoint(-1, 0, 0), tuple.NewPoint(1, 0, 0))
+	xs := tr.Intersects(ray.New(tuple.NewPoint(-0.2, 0.3, -2), tuple.NewVector(0, 0, 1)))
+
+	assertUV(t, &tr, tuple.NewPoint(-0.2, 0.3, 0), xs[0].u, xs[0].v)
+}
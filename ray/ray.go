@@ -9,10 +9,28 @@ type Ray struct {
 	origin       tuple.Tuple
 	direction    tuple.Tuple
 	invDirection tuple.Tuple
+	// time is the moment within the camera's shutter interval that the ray samples, which
+	// determines where moving objects are.
+	time float64
+	// wavelength is the wavelength of light in nanometres the ray carries once it has been split
+	// into colors by a dispersive material, or 0 for light of all wavelengths.
+	wavelength float64
 }
 
 func New(origin tuple.Tuple, direction tuple.Tuple) Ray {
-	return Ray{origin, direction, tuple.NewVector(1/direction.X, 1/direction.Y, 1/direction.Z)}
+	return Ray{origin, direction, tuple.NewVector(1/direction.X, 1/direction.Y, 1/direction.Z), 0, 0}
+}
+
+// WithTime returns a copy of the ray at the given time.
+func (r Ray) WithTime(time float64) Ray {
+	r.time = time
+	return r
+}
+
+// WithWavelength returns a copy of the ray carrying light of the given wavelength.
+func (r Ray) WithWavelength(wavelength float64) Ray {
+	r.wavelength = wavelength
+	return r
 }
 
 func (r Ray) Origin() tuple.Tuple {
@@ -27,10 +45,18 @@ func (r Ray) InvDirection() tuple.Tuple {
 	return r.invDirection
 }
 
+func (r Ray) Time() float64 {
+	return r.time
+}
+
+func (r Ray) Wavelength() float64 {
+	return r.wavelength
+}
+
 func (r Ray) Position(t float64) tuple.Tuple {
 	return r.origin.Add((r.direction).Mul(t))
 }
 
 func (r Ray) Transform(t matrix.Matrix) Ray {
-	return New(t.MulTuple(r.origin), t.MulTuple(r.direction))
+	return New(t.MulTuple(r.origin), t.MulTuple(r.direction)).WithTime(r.time).WithWavelength(r.wavelength)
 }
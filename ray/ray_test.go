@@ -46,3 +46,24 @@ func TestScalingRay(t *testing.T) {
 	assert.Equal(t, tuple.NewPoint(2, 6, 12), r2.Origin())
 	assert.Equal(t, tuple.NewVector(0, 3, 0), r2.Direction())
 }
+
+func TestRayTime(t *testing.T) {
+	r := New(tuple.NewPoint(1, 2, 3), tuple.NewVector(0, 1, 0))
+
+	r2 := r.WithTime(0.25)
+
+	assert.Equal(t, 0.0, r.Time())
+	assert.Equal(t, 0.25, r2.Time())
+	assert.Equal(t, r.Origin(), r2.Origin())
+	assert.Equal(t, 0.25, r2.Transform(transform.Translation(3, 4, 5)).Time())
+}
+
+func TestRayWavelength(t *testing.T) {
+	r := New(tuple.NewPoint(1, 2, 3), tuple.NewVector(0, 0, 1))
+	r2 := r.WithWavelength(486.1)
+
+	assert.Equal(t, 0.0, r.Wavelength())
+	assert.Equal(t, 486.1, r2.Wavelength())
+	// Transforming the ray keeps its wavelength.
+	assert.Equal(t, 486.1, r2.Transform(transform.Translation(3, 4, 5)).Wavelength())
+}
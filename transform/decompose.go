@@ -0,0 +1,99 @@
+package transform
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// TRS is a transform broken into a scale, followed by a rotation, followed by a translation.
+type TRS struct {
+	Translation tuple.Tuple
+	Rotation    Quaternion
+	Scale       tuple.Tuple
+}
+
+// Decompose breaks m into the translation, rotation and scale that make it. A reflection
+// becomes a negative x scale. Shearing can't be represented, so a matrix with shearing
+// decomposes into the rotation and scale closest to it.
+func Decompose(m matrix.Matrix) TRS {
+	trs, ok := decompose(m)
+	if !ok {
+		panic("cannot decompose a matrix that scales to 0")
+	}
+	return trs
+}
+
+// decompose is Decompose, returning false instead of panicking when m scales to 0.
+func decompose(m matrix.Matrix) (TRS, bool) {
+	column := func(i int) tuple.Tuple { return tuple.NewVector(m.At(0, i), m.At(1, i), m.At(2, i)) }
+	x, y, z := column(0), column(1), column(2)
+
+	// Gram-Schmidt takes out any shearing so what is left is a rotation.
+	sx := x.Mag()
+	if sx == 0 {
+		return TRS{}, false
+	}
+	x = x.Div(sx)
+	y = y.Sub(x.Mul(x.Dot(y)))
+	sy := y.Mag()
+	if sy == 0 {
+		return TRS{}, false
+	}
+	y = y.Div(sy)
+	z = z.Sub(x.Mul(x.Dot(z))).Sub(y.Mul(y.Dot(z)))
+	sz := z.Mag()
+	if sz == 0 {
+		return TRS{}, false
+	}
+	z = z.Div(sz)
+
+	if x.Cross(y).Dot(z) < 0 {
+		sx, x = -sx, x.Neg()
+	}
+	rotation := matrix.NewFromSlice([][]float64{
+		{x.X, y.X, z.X, 0},
+		{x.Y, y.Y, z.Y, 0},
+		{x.Z, y.Z, z.Z, 0},
+		{0, 0, 0, 1},
+	})
+	return TRS{
+		Translation: tuple.NewVector(m.At(0, 3), m.At(1, 3), m.At(2, 3)),
+		Rotation:    quaternionFromRotation(rotation),
+		Scale:       tuple.NewVector(sx, sy, sz),
+	}, true
+}
+
+// Matrix returns the transform that scales, then rotates, then translates.
+func (trs TRS) Matrix() matrix.Matrix {
+	return Identity().
+		Scaling(trs.Scale.X, trs.Scale.Y, trs.Scale.Z).
+		Rotation(trs.Rotation).
+		Translation(trs.Translation.X, trs.Translation.Y, trs.Translation.Z).
+		Matrix()
+}
+
+// Lerp interpolates between trs and trs2, linearly for translation, with Slerp for rotation
+// and geometrically for scale so that growing and shrinking look steady. It returns trs when
+// t is 0 and trs2 when t is 1.
+func (trs TRS) Lerp(trs2 TRS, t float64) TRS {
+	return TRS{
+		Translation: trs.Translation.Add(trs2.Translation.Sub(trs.Translation).Mul(t)),
+		Rotation:    trs.Rotation.Slerp(trs2.Rotation, t),
+		Scale: tuple.NewVector(
+			lerpScale(trs.Scale.X, trs2.Scale.X, t),
+			lerpScale(trs.Scale.Y, trs2.Scale.Y, t),
+			lerpScale(trs.Scale.Z, trs2.Scale.Z, t),
+		),
+	}
+}
+
+// lerpScale interpolates geometrically between scales of the same sign, and linearly
+// otherwise.
+func lerpScale(a, b, t float64) float64 {
+	if a*b <= 0 {
+		return a + (b-a)*t
+	}
+	return math.Copysign(math.Pow(math.Abs(a), 1-t)*math.Pow(math.Abs(b), t), a)
+}
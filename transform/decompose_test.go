@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecompose(t *testing.T) {
+	m := Identity().
+		Scaling(2, 3, 0.5).
+		RotationY(math.Pi/4).
+		RotationX(0.3).
+		Translation(1, -2, 5).
+		Matrix()
+
+	trs := Decompose(m)
+
+	test.AssertAlmost(t, tuple.NewVector(1, -2, 5), trs.Translation)
+	test.AssertAlmost(t, tuple.NewVector(2, 3, 0.5), trs.Scale)
+	assert.True(t, Euler(0.3, 0, 0).Mul(Euler(0, math.Pi/4, 0)).Matrix().Equals(trs.Rotation.Matrix()))
+	assert.True(t, m.Equals(trs.Matrix()))
+}
+
+func TestDecomposeReflection(t *testing.T) {
+	m := Identity().Scaling(1, -1, 1).RotationZ(1).Matrix()
+
+	trs := Decompose(m)
+
+	assert.Less(t, trs.Scale.X*trs.Scale.Y*trs.Scale.Z, 0.0)
+	assert.True(t, m.Equals(trs.Matrix()))
+}
+
+func TestDecomposeViewTransform(t *testing.T) {
+	m := ViewTransform(tuple.NewPoint(1, 3, 2), tuple.NewPoint(4, 3, 8), tuple.NewVector(0, 1, 0))
+
+	trs := Decompose(m)
+
+	test.AssertAlmost(t, tuple.NewVector(1, 1, 1), trs.Scale)
+	assert.True(t, m.Equals(trs.Matrix()))
+}
+
+func TestDecomposeDropsShearing(t *testing.T) {
+	trs := Decompose(Shearing(1, 0, 0, 0, 0, 0))
+
+	assert.True(t, trs.Rotation.Matrix().Mul(trs.Rotation.Matrix().Transpose()).Equals(matrix.Identity4()))
+}
+
+func TestDecomposeFluentTransform(t *testing.T) {
+	trs := Identity().Scaling(2, 2, 2).Rotation(Euler(1, 0, 0)).Decompose()
+
+	test.AssertAlmost(t, tuple.NewVector(2, 2, 2), trs.Scale)
+	assert.True(t, RotationX(1).Equals(trs.Rotation.Matrix()))
+}
+
+func TestLerpTRS(t *testing.T) {
+	start := TRS{tuple.NewVector(0, 0, 0), IdentityQuaternion, tuple.NewVector(1, 1, 1)}
+	end := TRS{tuple.NewVector(4, 0, 0), AxisAngle(tuple.NewVector(0, 1, 0), math.Pi/2), tuple.NewVector(4, 1, 1)}
+
+	mid := start.Lerp(end, 0.5)
+
+	test.AssertAlmost(t, tuple.NewVector(2, 0, 0), mid.Translation)
+	test.AssertAlmost(t, tuple.NewVector(2, 1, 1), mid.Scale)
+	assert.True(t, RotationY(math.Pi/4).Equals(mid.Rotation.Matrix()))
+}
+
+func TestFluentRotation(t *testing.T) {
+	m := Identity().Rotation(AxisAngle(tuple.NewVector(0, 0, 1), math.Pi/2)).Translation(1, 0, 0).Matrix()
+
+	test.AssertAlmost(t, tuple.NewPoint(1, 1, 0), m.MulTuple(tuple.NewPoint(1, 0, 0)))
+}
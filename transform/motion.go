@@ -0,0 +1,75 @@
+package transform
+
+import (
+	"sort"
+
+	"github.com/danieltmartin/ray-tracer/matrix"
+)
+
+// Key is a transform at a moment in time.
+type Key struct {
+	Time   float64
+	Matrix matrix.Matrix
+}
+
+// Motion is a transform that changes over time, given by keys between which it is interpolated
+// as TRS.Lerp does, so that rotating objects turn steadily without shrinking. Keys with
+// shearing, which TRS can't represent, are interpolated element by element instead. Before the
+// first key and after the last it holds still.
+type Motion struct {
+	keys []Key
+	// poses holds the keys broken into translation, rotation and scale, or is nil when a key
+	// can't be.
+	poses []TRS
+}
+
+// NewMotion creates a Motion through keys, which may be given in any order.
+func NewMotion(keys ...Key) Motion {
+	if len(keys) == 0 {
+		panic("motion needs at least one key")
+	}
+	sorted := make([]Key, len(keys))
+	copy(sorted, keys)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Time < sorted[j].Time })
+	return Motion{sorted, decomposeKeys(sorted)}
+}
+
+// decomposeKeys returns the pose of each key, or nil unless every key is exactly a pose.
+func decomposeKeys(keys []Key) []TRS {
+	poses := make([]TRS, len(keys))
+	for i, k := range keys {
+		trs, ok := decompose(k.Matrix)
+		if !ok || !trs.Matrix().Equals(k.Matrix) {
+			return nil
+		}
+		poses[i] = trs
+	}
+	return poses
+}
+
+// Between creates a Motion from start at time 0 to end at time 1, the usual shutter interval.
+func Between(start, end matrix.Matrix) Motion {
+	return NewMotion(Key{0, start}, Key{1, end})
+}
+
+// Keys returns the keys of the motion in time order.
+func (m Motion) Keys() []Key {
+	return m.keys
+}
+
+// At returns the transform at time.
+func (m Motion) At(time float64) matrix.Matrix {
+	i := sort.Search(len(m.keys), func(i int) bool { return m.keys[i].Time > time })
+	if i == 0 {
+		return m.keys[0].Matrix
+	}
+	if i == len(m.keys) {
+		return m.keys[len(m.keys)-1].Matrix
+	}
+	k1, k2 := m.keys[i-1], m.keys[i]
+	t := (time - k1.Time) / (k2.Time - k1.Time)
+	if m.poses == nil {
+		return k1.Matrix.Lerp(k2.Matrix, t)
+	}
+	return m.poses[i-1].Lerp(m.poses[i], t).Matrix()
+}
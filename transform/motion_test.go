@@ -0,0 +1,68 @@
+package transform
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMotionBetweenTwoKeys(t *testing.T) {
+	m := Between(Translation(0, 0, 0), Translation(4, 0, 0))
+
+	origin := tuple.NewPoint(0, 0, 0)
+	assert.Equal(t, tuple.NewPoint(0, 0, 0), m.At(0).MulTuple(origin))
+	assert.Equal(t, tuple.NewPoint(1, 0, 0), m.At(0.25).MulTuple(origin))
+	assert.Equal(t, tuple.NewPoint(4, 0, 0), m.At(1).MulTuple(origin))
+}
+
+func TestMotionHoldsStillOutsideKeys(t *testing.T) {
+	m := NewMotion(Key{1, Translation(1, 0, 0)}, Key{2, Translation(2, 0, 0)})
+
+	assert.Equal(t, Translation(1, 0, 0), m.At(-5))
+	assert.Equal(t, Translation(2, 0, 0), m.At(5))
+}
+
+func TestMotionWithManyKeysInAnyOrder(t *testing.T) {
+	m := NewMotion(
+		Key{2, Translation(0, 4, 0)},
+		Key{0, Translation(0, 0, 0)},
+		Key{1, Translation(2, 0, 0)},
+	)
+
+	assert.Equal(t, []float64{0, 1, 2}, []float64{m.Keys()[0].Time, m.Keys()[1].Time, m.Keys()[2].Time})
+	origin := tuple.NewPoint(0, 0, 0)
+	assert.Equal(t, tuple.NewPoint(1, 0, 0), m.At(0.5).MulTuple(origin))
+	assert.Equal(t, tuple.NewPoint(1, 2, 0), m.At(1.5).MulTuple(origin))
+	assert.Equal(t, Translation(2, 0, 0), m.At(1))
+}
+
+func TestMotionWithoutKeysPanics(t *testing.T) {
+	assert.Panics(t, func() { NewMotion() })
+}
+
+func TestMotionTurnsWithoutShrinking(t *testing.T) {
+	m := Between(Identity().Matrix(), RotationZ(math.Pi/2))
+
+	p := m.At(0.5).MulTuple(tuple.NewPoint(1, 0, 0))
+
+	test.AssertAlmost(t, tuple.NewPoint(math.Sqrt2/2, math.Sqrt2/2, 0), p)
+}
+
+func TestMotionInterpolatesScaleAndTranslationWithRotation(t *testing.T) {
+	start := Identity().Matrix()
+	end := Identity().Scaling(4, 4, 4).RotationY(math.Pi/2).Translation(2, 0, 0).Matrix()
+	m := Between(start, end)
+
+	expected := Identity().Scaling(2, 2, 2).RotationY(math.Pi/4).Translation(1, 0, 0).Matrix()
+	assert.True(t, expected.Equals(m.At(0.5)))
+}
+
+func TestMotionWithShearingInterpolatesElements(t *testing.T) {
+	sheared := Shearing(1, 0, 0, 0, 0, 0)
+	m := Between(Identity().Matrix(), sheared)
+
+	assert.Equal(t, Identity().Matrix().Lerp(sheared, 0.5), m.At(0.5))
+}
@@ -0,0 +1,123 @@
+package transform
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/matrix"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// Quaternion is a rotation, which unlike a rotation matrix can be interpolated smoothly with
+// Slerp. Quaternions made by the functions in this package have unit length.
+type Quaternion struct {
+	W, X, Y, Z float64
+}
+
+// IdentityQuaternion is no rotation.
+var IdentityQuaternion = Quaternion{1, 0, 0, 0}
+
+// AxisAngle creates a Quaternion that rotates by radians around axis, turning the same way as
+// RotationX, RotationY and RotationZ do around their axes.
+func AxisAngle(axis tuple.Tuple, radians float64) Quaternion {
+	axis = tuple.NewVector(axis.X, axis.Y, axis.Z).Norm()
+	s := math.Sin(radians / 2)
+	return Quaternion{math.Cos(radians / 2), axis.X * s, axis.Y * s, axis.Z * s}
+}
+
+// Euler creates a Quaternion that rotates by x radians around the x axis, then y around the y
+// axis, then z around the z axis, like Identity().RotationX(x).RotationY(y).RotationZ(z).
+func Euler(x, y, z float64) Quaternion {
+	qx := AxisAngle(tuple.NewVector(1, 0, 0), x)
+	qy := AxisAngle(tuple.NewVector(0, 1, 0), y)
+	qz := AxisAngle(tuple.NewVector(0, 0, 1), z)
+	return qz.Mul(qy).Mul(qx)
+}
+
+// Mul returns the rotation by q2 followed by the rotation by q.
+func (q Quaternion) Mul(q2 Quaternion) Quaternion {
+	return Quaternion{
+		q.W*q2.W - q.X*q2.X - q.Y*q2.Y - q.Z*q2.Z,
+		q.W*q2.X + q.X*q2.W + q.Y*q2.Z - q.Z*q2.Y,
+		q.W*q2.Y - q.X*q2.Z + q.Y*q2.W + q.Z*q2.X,
+		q.W*q2.Z + q.X*q2.Y - q.Y*q2.X + q.Z*q2.W,
+	}
+}
+
+// Conjugate returns the opposite rotation to q.
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{q.W, -q.X, -q.Y, -q.Z}
+}
+
+func (q Quaternion) Dot(q2 Quaternion) float64 {
+	return q.W*q2.W + q.X*q2.X + q.Y*q2.Y + q.Z*q2.Z
+}
+
+func (q Quaternion) Mag() float64 {
+	return math.Sqrt(q.Dot(q))
+}
+
+func (q Quaternion) Norm() Quaternion {
+	mag := q.Mag()
+	return Quaternion{q.W / mag, q.X / mag, q.Y / mag, q.Z / mag}
+}
+
+// Slerp interpolates between the rotations q and q2 at a constant angular speed along the
+// shortest way round, returning q when t is 0 and q2 when t is 1.
+func (q Quaternion) Slerp(q2 Quaternion, t float64) Quaternion {
+	dot := q.Dot(q2)
+	if dot < 0 {
+		// q2 and -q2 are the same rotation, but only one is the short way round from q.
+		q2 = Quaternion{-q2.W, -q2.X, -q2.Y, -q2.Z}
+		dot = -dot
+	}
+	var a, b float64
+	if dot > 0.9995 {
+		// The angle is too small to divide by its sine, and a straight line is as good.
+		a, b = 1-t, t
+	} else {
+		angle := math.Acos(dot)
+		sin := math.Sin(angle)
+		a, b = math.Sin((1-t)*angle)/sin, math.Sin(t*angle)/sin
+	}
+	return Quaternion{
+		a*q.W + b*q2.W,
+		a*q.X + b*q2.X,
+		a*q.Y + b*q2.Y,
+		a*q.Z + b*q2.Z,
+	}.Norm()
+}
+
+// Matrix returns the rotation matrix of q.
+func (q Quaternion) Matrix() matrix.Matrix {
+	q = q.Norm()
+	w, x, y, z := q.W, q.X, q.Y, q.Z
+	return matrix.NewFromSlice([][]float64{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y), 0},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x), 0},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y), 0},
+		{0, 0, 0, 1},
+	})
+}
+
+// quaternionFromRotation returns the rotation of the upper 3x3 of m, which must be a pure
+// rotation matrix.
+func quaternionFromRotation(m matrix.Matrix) Quaternion {
+	m00, m11, m22 := m.At(0, 0), m.At(1, 1), m.At(2, 2)
+	var q Quaternion
+	// Divide by the largest of the four terms to keep the result accurate.
+	switch trace := m00 + m11 + m22; {
+	case trace > 0:
+		s := 2 * math.Sqrt(1+trace)
+		q = Quaternion{s / 4, (m.At(2, 1) - m.At(1, 2)) / s, (m.At(0, 2) - m.At(2, 0)) / s, (m.At(1, 0) - m.At(0, 1)) / s}
+	case m00 > m11 && m00 > m22:
+		s := 2 * math.Sqrt(1+m00-m11-m22)
+		q = Quaternion{(m.At(2, 1) - m.At(1, 2)) / s, s / 4, (m.At(0, 1) + m.At(1, 0)) / s, (m.At(0, 2) + m.At(2, 0)) / s}
+	case m11 > m22:
+		s := 2 * math.Sqrt(1+m11-m00-m22)
+		q = Quaternion{(m.At(0, 2) - m.At(2, 0)) / s, (m.At(0, 1) + m.At(1, 0)) / s, s / 4, (m.At(1, 2) + m.At(2, 1)) / s}
+	default:
+		s := 2 * math.Sqrt(1+m22-m00-m11)
+		q = Quaternion{(m.At(1, 0) - m.At(0, 1)) / s, (m.At(0, 2) + m.At(2, 0)) / s, (m.At(1, 2) + m.At(2, 1)) / s, s / 4}
+	}
+	return q.Norm()
+}
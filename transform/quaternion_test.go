@@ -0,0 +1,84 @@
+package transform
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/float"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAxisAngleMatchesAxisRotations(t *testing.T) {
+	assert.True(t, RotationX(math.Pi/3).Equals(AxisAngle(tuple.NewVector(1, 0, 0), math.Pi/3).Matrix()))
+	assert.True(t, RotationY(math.Pi/3).Equals(AxisAngle(tuple.NewVector(0, 1, 0), math.Pi/3).Matrix()))
+	assert.True(t, RotationZ(math.Pi/3).Equals(AxisAngle(tuple.NewVector(0, 0, 1), math.Pi/3).Matrix()))
+}
+
+func TestAxisAngleAroundAnyAxis(t *testing.T) {
+	q := AxisAngle(tuple.NewVector(1, 1, 1), 2*math.Pi/3)
+
+	test.AssertAlmost(t, tuple.NewPoint(0, 1, 0), q.Matrix().MulTuple(tuple.NewPoint(1, 0, 0)))
+	test.AssertAlmost(t, tuple.NewPoint(1, 1, 1), q.Matrix().MulTuple(tuple.NewPoint(1, 1, 1)))
+}
+
+func TestEulerMatchesFluentRotations(t *testing.T) {
+	expected := Identity().RotationX(0.3).RotationY(-1.2).RotationZ(2).Matrix()
+
+	assert.True(t, expected.Equals(Euler(0.3, -1.2, 2).Matrix()))
+}
+
+func TestMultiplyQuaternions(t *testing.T) {
+	q := AxisAngle(tuple.NewVector(0, 1, 0), math.Pi/2)
+	q2 := AxisAngle(tuple.NewVector(1, 0, 0), math.Pi/2)
+
+	assert.True(t, q.Matrix().Mul(q2.Matrix()).Equals(q.Mul(q2).Matrix()))
+}
+
+func TestConjugateIsOppositeRotation(t *testing.T) {
+	q := Euler(0.5, 1, 1.5)
+
+	assert.True(t, IdentityQuaternion.Matrix().Equals(q.Mul(q.Conjugate()).Matrix()))
+}
+
+func TestSlerp(t *testing.T) {
+	q := IdentityQuaternion
+	q2 := AxisAngle(tuple.NewVector(0, 0, 1), math.Pi/2)
+
+	assert.True(t, q.Matrix().Equals(q.Slerp(q2, 0).Matrix()))
+	assert.True(t, q2.Matrix().Equals(q.Slerp(q2, 1).Matrix()))
+	assert.True(t, RotationZ(math.Pi/6).Equals(q.Slerp(q2, 1.0/3).Matrix()))
+	// Unlike interpolating matrices, points keep their distance from the origin.
+	test.AssertAlmost(t, 1.0, q.Slerp(q2, 0.5).Matrix().MulTuple(tuple.NewPoint(1, 0, 0)).Mag())
+}
+
+func TestSlerpTakesShortestWay(t *testing.T) {
+	q := AxisAngle(tuple.NewVector(0, 1, 0), math.Pi/4)
+	q2 := AxisAngle(tuple.NewVector(0, 1, 0), -math.Pi/4)
+	negated := Quaternion{-q2.W, -q2.X, -q2.Y, -q2.Z}
+
+	assert.True(t, IdentityQuaternion.Matrix().Equals(q.Slerp(negated, 0.5).Matrix()))
+}
+
+func TestSlerpBetweenCloseRotations(t *testing.T) {
+	q := AxisAngle(tuple.NewVector(0, 1, 0), 0.01)
+	q2 := AxisAngle(tuple.NewVector(0, 1, 0), 0.02)
+
+	assert.True(t, RotationY(0.015).Equals(q.Slerp(q2, 0.5).Matrix()))
+	assert.True(t, float.Equal(1, q.Slerp(q2, 0.5).Mag()))
+}
+
+func TestQuaternionFromRotation(t *testing.T) {
+	rotations := []Quaternion{
+		IdentityQuaternion,
+		AxisAngle(tuple.NewVector(1, 0, 0), math.Pi),
+		AxisAngle(tuple.NewVector(0, 1, 0), math.Pi),
+		AxisAngle(tuple.NewVector(0, 0, 1), math.Pi),
+		Euler(0.3, -1.2, 2),
+		Euler(3, 2.5, -2.8),
+	}
+	for _, q := range rotations {
+		assert.True(t, q.Matrix().Equals(quaternionFromRotation(q.Matrix()).Matrix()), "%v", q)
+	}
+}
@@ -40,6 +40,12 @@ func (t Transform) RotationZ(radians float64) Transform {
 	return t
 }
 
+// Rotation rotates by q, which allows rotating around any axis.
+func (t Transform) Rotation(q Quaternion) Transform {
+	t.m = Rotation(q).Mul(t.m)
+	return t
+}
+
 func (t Transform) Shearing(xy, xz, yx, yz, zx, zy float64) Transform {
 	t.m = Shearing(xy, xz, yx, yz, zx, zy).Mul(t.m)
 	return t
@@ -49,6 +55,11 @@ func (t Transform) Matrix() matrix.Matrix {
 	return t.m.Copy()
 }
 
+// Decompose breaks the transform into its translation, rotation and scale.
+func (t Transform) Decompose() TRS {
+	return Decompose(t.m)
+}
+
 func Translation(x, y, z float64) matrix.Matrix {
 	return matrix.NewFromSlice([][]float64{
 		{1, 0, 0, x},
@@ -97,6 +108,10 @@ func RotationZ(radians float64) matrix.Matrix {
 	})
 }
 
+func Rotation(q Quaternion) matrix.Matrix {
+	return q.Matrix()
+}
+
 func Shearing(xy, xz, yx, yz, zx, zy float64) matrix.Matrix {
 	return matrix.NewFromSlice([][]float64{
 		{1, xy, xz, 0},
@@ -0,0 +1,58 @@
+package world
+
+import (
+	"math"
+
+	"github.com/danieltmartin/ray-tracer/environment"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// SetBackground sets the color seen by primary, reflected and refracted rays that escape the
+// scene without hitting anything. Without a background they see black.
+func (w *World) SetBackground(b environment.Background) {
+	w.background = b
+}
+
+func (w *World) Background() environment.Background {
+	return w.background
+}
+
+// SetEnvironmentLighting makes the background illuminate surfaces as a light source, giving
+// them diffuse lighting from every direction in which the background is visible. The background
+// is sampled in the given number of directions at each hit; more samples reduce noise. Zero
+// disables environment lighting.
+func (w *World) SetEnvironmentLighting(samples int) {
+	if samples < 0 {
+		panic("environment samples must not be negative")
+	}
+	w.environmentSamples = samples
+}
+
+func (w *World) backgroundColor(direction tuple.Tuple) floatcolor.Float64Color {
+	if w.background == nil {
+		return floatcolor.Black
+	}
+	return w.background.ColorAt(direction)
+}
+
+// environmentRadiance estimates the average radiance arriving at the hit from the background,
+// weighted by the cosine of its angle to the normal. Paths that have already branched at a
+// rough surface take a single sample, as the samples of the branches are averaged together.
+func (w *World) environmentRadiance(hc hitComputations) floatcolor.Float64Color {
+	samples := w.environmentSamples
+	if hc.branched {
+		samples = 1
+	}
+	total := floatcolor.Black
+	for i := 0; i < samples; i++ {
+		w.stats.environmentRayCount.inc()
+		direction := cosineWeightedDirection(hc.normalv)
+		if w.blocked(ray.New(hc.overPoint, direction).WithTime(hc.time), math.Inf(1)) {
+			continue
+		}
+		total = total.Add(w.background.ColorAt(direction))
+	}
+	return total.Mul(1 / float64(samples))
+}
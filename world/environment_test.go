@@ -0,0 +1,84 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/environment"
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/material"
+	"github.com/danieltmartin/ray-tracer/primitive"
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRayMissingEverythingSeesBackground(t *testing.T) {
+	w := testWorld()
+	w.SetBackground(environment.NewGradient(floatcolor.Black, floatcolor.White, floatcolor.New(0, 0, 1)))
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 1, 0))
+
+	c := w.ColorAt(r, 5)
+
+	test.AssertAlmost(t, floatcolor.New(0, 0, 1), c)
+}
+
+func TestRayMissingEverythingWithoutBackgroundIsBlack(t *testing.T) {
+	w := testWorld()
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 1, 0))
+
+	assert.Equal(t, floatcolor.Black, w.ColorAt(r, 5))
+}
+
+func TestReflectiveSurfaceReflectsBackground(t *testing.T) {
+	w := New()
+	w.SetBackground(environment.Constant(floatcolor.New(0.2, 0.4, 0.6)))
+	p := primitive.NewPlane()
+	p.SetMaterial(material.Default.
+		WithAmbient(0).
+		WithDiffuse(0).
+		WithSpecular(0).
+		WithReflective(0.5))
+	w.AddPrimitives(&p)
+	r := ray.New(tuple.NewPoint(0, 1, -1), tuple.NewVector(0, -1, 1).Norm())
+
+	c := w.ColorAt(r, 5)
+
+	test.AssertAlmost(t, floatcolor.New(0.1, 0.2, 0.3), c)
+}
+
+func TestEnvironmentLightingIlluminatesExposedSurfaces(t *testing.T) {
+	w := New()
+	w.SetBackground(environment.Constant(floatcolor.White))
+	w.SetEnvironmentLighting(8)
+	s := primitive.NewSphere()
+	s.SetMaterial(material.Default.
+		WithColor(floatcolor.New(1, 0.5, 0.25)).
+		WithAmbient(0).
+		WithDiffuse(0.8))
+	w.AddPrimitives(&s)
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	c := w.ColorAt(r, 5)
+
+	test.AssertAlmost(t, floatcolor.New(0.8, 0.4, 0.2), c)
+	assert.Equal(t, uint64(8), w.Stats().EnvironmentRayCount())
+	assert.Equal(t, uint64(0), w.Stats().ShadowRayCount())
+}
+
+func TestEnvironmentLightingIsBlockedByOccluders(t *testing.T) {
+	w := New()
+	w.SetBackground(environment.Constant(floatcolor.White))
+	w.SetEnvironmentLighting(8)
+	floor := primitive.NewPlane()
+	floor.SetMaterial(material.Default.WithAmbient(0))
+	ceiling := primitive.NewPlane()
+	ceiling.SetTransform(transform.Translation(0, 1, 0))
+	w.AddPrimitives(&floor, &ceiling)
+	r := ray.New(tuple.NewPoint(0, 0.5, 0), tuple.NewVector(0, -1, 0))
+
+	c := w.ColorAt(r, 5)
+
+	assert.Equal(t, floatcolor.Black, c)
+}
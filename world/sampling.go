@@ -0,0 +1,85 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/danieltmartin/ray-tracer/float"
+	"github.com/danieltmartin/ray-tracer/material"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// randomInUnitSphere returns a random vector with a magnitude of at most 1.
+func randomInUnitSphere() tuple.Tuple {
+	for {
+		v := tuple.NewVector(2*rand.Float64()-1, 2*rand.Float64()-1, 2*rand.Float64()-1)
+		if v.Dot(v) <= 1 {
+			return v
+		}
+	}
+}
+
+// cosineWeightedDirection returns a random direction in the hemisphere around normal, with
+// directions close to the normal more likely in proportion to the cosine of their angle to it.
+func cosineWeightedDirection(normal tuple.Tuple) tuple.Tuple {
+	for {
+		// Offsetting the normal by a random point on the unit sphere gives a cosine distribution.
+		v := randomInUnitSphere()
+		if v.Mag() < float.Epsilon {
+			continue
+		}
+		direction := normal.Add(v.Norm())
+		if direction.Mag() > float.Epsilon {
+			return direction.Norm()
+		}
+	}
+}
+
+// maxLobeAttempts bounds how many microfacets are tried for a scattered ray that leaves the
+// surface on the correct side, before falling back to the smooth direction.
+const maxLobeAttempts = 8
+
+// glossyReflection returns a direction reflected from a rough surface, by reflecting eyev about
+// a microfacet normal drawn from the GGX distribution for the roughness. It falls back to
+// mirror, the direction for a smooth surface, when none of the reflections leave the surface.
+func glossyReflection(eyev, normal, mirror tuple.Tuple, roughness float64) tuple.Tuple {
+	for i := 0; i < maxLobeAttempts; i++ {
+		h := material.SampleMicrofacetNormal(normal, roughness, rand.Float64(), rand.Float64())
+		direction := h.Mul(2 * eyev.Dot(h)).Sub(eyev)
+		if direction.Dot(normal) > 0 {
+			return direction
+		}
+	}
+	return mirror
+}
+
+// frostedRefraction returns a direction refracted through a rough surface, by refracting eyev
+// through a microfacet normal drawn from the GGX distribution for the roughness, with nRatio
+// the ratio of the refractive indices either side. It falls back to smooth, the direction for
+// a smooth surface, when every microfacet tried reflects the ray totally or sends it back out.
+func frostedRefraction(eyev, normal, smooth tuple.Tuple, nRatio, roughness float64) tuple.Tuple {
+	for i := 0; i < maxLobeAttempts; i++ {
+		h := material.SampleMicrofacetNormal(normal, roughness, rand.Float64(), rand.Float64())
+		cosi := eyev.Dot(h)
+		sin2t := nRatio * nRatio * (1 - cosi*cosi)
+		if cosi <= 0 || sin2t > 1 {
+			continue
+		}
+		direction := h.Mul(nRatio*cosi - math.Sqrt(1-sin2t)).Sub(eyev.Mul(nRatio))
+		if direction.Dot(normal) < 0 {
+			return direction.Norm()
+		}
+	}
+	return smooth
+}
+
+// lobeSamples returns how many rays to trace through the reflection or refraction lobe of m.
+// Only the first rough surface along a path is sampled many times; once a path has branched,
+// each later rough surface traces a single ray, so the number of rays doesn't grow
+// exponentially with depth.
+func lobeSamples(m material.Material, branched bool) int {
+	if m.Roughness() == 0 || branched {
+		return 1
+	}
+	return m.RoughnessSamples()
+}
@@ -0,0 +1,60 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/material"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRandomInUnitSphereHasMagnitudeAtMostOne(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		v := randomInUnitSphere()
+
+		assert.LessOrEqual(t, v.Mag(), 1.0)
+		assert.True(t, v.IsVector())
+	}
+}
+
+func TestGlossyReflectionLeavesSurface(t *testing.T) {
+	normal := tuple.NewVector(0, 1, 0)
+	eyev := tuple.NewVector(-1, 0.05, 0).Norm()
+	mirror := tuple.NewVector(1, 0.05, 0).Norm()
+
+	for i := 0; i < 1000; i++ {
+		d := glossyReflection(eyev, normal, mirror, 1)
+
+		assert.Greater(t, d.Dot(normal), 0.0)
+		assert.InDelta(t, 1.0, d.Mag(), 0.0001)
+	}
+}
+
+func TestGlossyReflectionWithSmallRoughnessStaysCloseToMirror(t *testing.T) {
+	normal := tuple.NewVector(0, 1, 0)
+	eyev := tuple.NewVector(0, 1, -1).Norm()
+	mirror := tuple.NewVector(0, 1, 1).Norm()
+
+	for i := 0; i < 100; i++ {
+		assert.Greater(t, glossyReflection(eyev, normal, mirror, 0.05).Dot(mirror), 0.99)
+	}
+}
+
+func TestFrostedRefractionEntersSurface(t *testing.T) {
+	normal := tuple.NewVector(0, 1, 0)
+	eyev := tuple.NewVector(0, 1, -1).Norm()
+	smooth := tuple.NewVector(0, -1, 0)
+
+	for i := 0; i < 1000; i++ {
+		d := frostedRefraction(eyev, normal, smooth, 1/1.5, 0.8)
+
+		assert.Less(t, d.Dot(normal), 0.0)
+		assert.InDelta(t, 1.0, d.Mag(), 0.0001)
+	}
+}
+
+func TestLobeSamples(t *testing.T) {
+	assert.Equal(t, 1, lobeSamples(material.Default.WithRoughnessSamples(10), false))
+	assert.Equal(t, 10, lobeSamples(material.Default.WithRoughness(0.1).WithRoughnessSamples(10), false))
+	assert.Equal(t, 1, lobeSamples(material.Default.WithRoughness(0.1).WithRoughnessSamples(10), true))
+}
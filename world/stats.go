@@ -25,6 +25,8 @@ type Stats struct {
 	shadowRayCount     counter
 	reflectionRayCount counter
 	refractionRayCount counter
+	// environmentRayCount counts the rays that sample the background for image based lighting.
+	environmentRayCount counter
 }
 
 func (s *Stats) EyeRayCount() uint64 {
@@ -40,11 +42,17 @@ func (s *Stats) RefractionRayCount() uint64 {
 	return s.refractionRayCount.val()
 }
 
+func (s *Stats) EnvironmentRayCount() uint64 {
+	return s.environmentRayCount.val()
+}
+
 func (s *Stats) TotalRayCount() uint64 {
-	return s.eyeRayCount.val() + s.shadowRayCount.val() + s.reflectionRayCount.val() + s.refractionRayCount.val()
+	return s.eyeRayCount.val() + s.shadowRayCount.val() + s.reflectionRayCount.val() + s.refractionRayCount.val() +
+		s.environmentRayCount.val()
 }
 
 func (s *Stats) Log() {
-	log.Printf("eye rays: %v, shadow rays: %v, reflection rays: %v, refraction rays: %v, total: %v",
-		s.eyeRayCount.val(), s.shadowRayCount.val(), s.reflectionRayCount.val(), s.refractionRayCount.val(), s.TotalRayCount())
+	log.Printf("eye rays: %v, shadow rays: %v, reflection rays: %v, refraction rays: %v, environment rays: %v, total: %v",
+		s.eyeRayCount.val(), s.shadowRayCount.val(), s.reflectionRayCount.val(), s.refractionRayCount.val(),
+		s.environmentRayCount.val(), s.TotalRayCount())
 }
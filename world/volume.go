@@ -0,0 +1,154 @@
+package world
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/light"
+	"github.com/danieltmartin/ray-tracer/material"
+	"github.com/danieltmartin/ray-tracer/primitive"
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/tuple"
+)
+
+// volumeSteps is the number of points along a ray at which scattered light is sampled when the
+// ray passes through a participating medium.
+const volumeSteps = 16
+
+// minVolumeTransmittance is the fraction of light below which a medium is considered opaque.
+// It bounds how far rays that escape the scene are marched through fog.
+const minVolumeTransmittance = 1e-6
+
+// SetFog fills the empty space between objects with a homogeneous participating medium
+// described by m's color, ambient and volume density. Light from each light source is scattered
+// by the fog wherever the light isn't shadowed, producing haze and light shafts. The fog dims
+// light on its way from point lights, while directional lights are taken to shine in from
+// outside it.
+func (w *World) SetFog(m material.Material) {
+	w.fog = m
+}
+
+// throughMedium attenuates color, arriving from distance along r, by the medium it travelled
+// through and adds any light scattered towards the ray's origin along the way. A nil medium
+// means empty space, which may contain fog.
+func (w *World) throughMedium(r ray.Ray, distance float64, medium primitive.Primitive, color floatcolor.Float64Color) floatcolor.Float64Color {
+	if medium == nil {
+		if w.fog.VolumeDensity() == 0 {
+			return color
+		}
+		scattered, transmittance := w.scatter(r, distance, w.fog, worldSpace{})
+		return scattered.Add(color.Mul(transmittance))
+	}
+
+	m := medium.Material()
+	if m.VolumeDensity() > 0 {
+		scattered, transmittance := w.scatter(r, distance, m, primitive.AtTime(medium, r.Time()))
+		return scattered.Add(color.Mul(transmittance))
+	}
+	return color.Hadamard(materialAt(medium, r.Time(), r.Position(distance/2)).Transmittance(distance))
+}
+
+// scatter estimates the light scattered towards the origin of r by a medium between the origin
+// and distance along the ray. It also returns the fraction of light from beyond that
+// distance that makes it through the medium.
+//
+// Only single scattering is modelled: each sample point receives light directly from the light
+// sources it isn't shadowed from, scattered equally in all directions.
+func (w *World) scatter(r ray.Ray, distance float64, medium material.Material, object material.Object) (floatcolor.Float64Color, float64) {
+	density := medium.VolumeDensity()
+	maxDistance := -math.Log(minVolumeTransmittance) / density
+	if distance > maxDistance {
+		distance = maxDistance
+	}
+	if distance <= 0 {
+		return floatcolor.Black, 1
+	}
+
+	step := distance / volumeSteps
+	scattered := floatcolor.Black
+	transmittance := 1.0
+	for i := 0; i < volumeSteps; i++ {
+		// Sample a random point within each step to avoid banding.
+		p := r.Position((float64(i) + rand.Float64()) * step)
+		at := medium.At(object, p)
+		stepTransmittance := math.Exp(-at.VolumeDensity() * step)
+		albedo := at.ColorAt(object, p)
+		light := albedo.Mul(at.Ambient())
+		for _, l := range w.lights {
+			if l == nil {
+				continue
+			}
+			if lt := w.lightTransmittance(p, l, r.Time()); lt > 0 {
+				light = light.Add(albedo.Hadamard(l.Intensity()).Mul(lt))
+			}
+		}
+
+		// Weight by the fraction of light scattered within this step that isn't absorbed again
+		// before reaching the ray's origin.
+		scattered = scattered.Add(light.Mul(transmittance * (1 - stepTransmittance)))
+		transmittance *= stepTransmittance
+	}
+
+	if distance == maxDistance {
+		transmittance = 0
+	}
+	return scattered, transmittance
+}
+
+// lightTransmittance returns the fraction of the light from l that reaches p at time. It is 0
+// when a surface blocks the light, and otherwise the fraction that isn't absorbed or scattered
+// away by the volumes and fog the light passes through.
+func (w *World) lightTransmittance(p tuple.Tuple, l *light.PointLight, time float64) float64 {
+	w.stats.shadowRayCount.inc()
+	maxDistance := l.DistanceFrom(p)
+	r := ray.New(p, l.DirectionFrom(p)).WithTime(time)
+	xns := w.intersect(r)
+	defer func() {
+		reslice := xns[:0]
+		intersectionPool.Put(&reslice)
+	}()
+
+	// Intersections come in pairs for each volume, entering and then leaving it, including
+	// those behind p when p is inside a volume.
+	entered := make(map[primitive.Primitive]float64)
+	opticalDepth := 0.0
+	inVolumes := 0.0
+	for _, x := range xns {
+		if !isVolume(x.Object()) {
+			if x.Distance() >= 0 && x.Distance() < maxDistance {
+				return 0
+			}
+			continue
+		}
+		entry, inside := entered[x.Object()]
+		if !inside {
+			entered[x.Object()] = x.Distance()
+			continue
+		}
+		delete(entered, x.Object())
+		start, end := math.Max(entry, 0), math.Min(x.Distance(), maxDistance)
+		if end > start {
+			// Patchy volumes are taken to have the density at the middle of the segment.
+			density := materialAt(x.Object(), time, r.Position((start+end)/2)).VolumeDensity()
+			opticalDepth += density * (end - start)
+			inVolumes += end - start
+		}
+	}
+	if w.fog.VolumeDensity() > 0 && !l.IsDirectional() {
+		density := w.fog.At(worldSpace{}, r.Position(maxDistance/2)).VolumeDensity()
+		opticalDepth += density * (maxDistance - inVolumes)
+	}
+	return math.Exp(-opticalDepth)
+}
+
+func isVolume(p primitive.Primitive) bool {
+	return p.Material().VolumeDensity() > 0
+}
+
+// worldSpace is used to evaluate patterns in world coordinates, such as the color of fog.
+type worldSpace struct{}
+
+func (worldSpace) WorldPointToLocal(p tuple.Tuple) tuple.Tuple {
+	return p
+}
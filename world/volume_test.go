@@ -0,0 +1,176 @@
+package world
+
+import (
+	"math"
+	"testing"
+
+	"github.com/danieltmartin/ray-tracer/floatcolor"
+	"github.com/danieltmartin/ray-tracer/light"
+	"github.com/danieltmartin/ray-tracer/material"
+	"github.com/danieltmartin/ray-tracer/primitive"
+	"github.com/danieltmartin/ray-tracer/ray"
+	"github.com/danieltmartin/ray-tracer/test"
+	"github.com/danieltmartin/ray-tracer/transform"
+	"github.com/danieltmartin/ray-tracer/tuple"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRayMissingEverythingInFogSeesFogColor(t *testing.T) {
+	w := New()
+	w.SetFog(material.Default.
+		WithColor(floatcolor.New(0.5, 0.6, 0.7)).
+		WithAmbient(1).
+		WithVolumeDensity(0.1))
+	r := ray.New(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, 1))
+
+	c := w.ColorAt(r, 5)
+
+	test.AssertAlmost(t, floatcolor.New(0.5, 0.6, 0.7), c)
+}
+
+func TestFogAttenuatesDistantSurfaces(t *testing.T) {
+	w := testWorld()
+	// Lit by ambient light only, so that the fog dimming the light source doesn't matter.
+	w.primitives[0].SetMaterial(material.Default.WithAmbient(1).WithDiffuse(0).WithSpecular(0))
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+	clear := w.ColorAt(r, 1)
+	w.SetFog(material.Default.
+		WithColor(floatcolor.Black).
+		WithVolumeDensity(0.5))
+
+	c := w.ColorAt(r, 1)
+
+	// The surface is 4 units away
+	test.AssertAlmost(t, clear.Mul(math.Exp(-2)), c)
+}
+
+func TestFogScattersLightWhereNotShadowed(t *testing.T) {
+	w := New()
+	l := light.NewPointLight(tuple.NewPoint(0, 5, 5), floatcolor.White)
+	w.AddLights(&l)
+	w.SetFog(material.Default.
+		WithAmbient(0).
+		WithVolumeDensity(0.1))
+	r := ray.New(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, 1))
+
+	lit := w.ColorAt(r, 1)
+
+	blocker := primitive.NewPlane()
+	blocker.SetTransform(transform.Translation(0, 2, 0))
+	w.AddPrimitives(&blocker)
+	shadowed := w.ColorAt(r, 1)
+
+	litR, _, _ := lit.RGB()
+	assert.Greater(t, litR, 0.1)
+	assert.Equal(t, floatcolor.Black, shadowed)
+}
+
+func TestColorThroughConstantDensityVolume(t *testing.T) {
+	w := New()
+	smoke := primitive.NewSphere()
+	smoke.SetMaterial(material.Default.
+		WithAmbient(1).
+		WithVolumeDensity(0.5))
+	w.AddPrimitives(&smoke)
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	c := w.ColorAt(r, 1)
+
+	// The ray travels 2 units through the sphere
+	expected := 1 - math.Exp(-1)
+	test.AssertAlmost(t, floatcolor.New(expected, expected, expected), c)
+}
+
+func TestObjectInsideVolumeIsPartiallyObscured(t *testing.T) {
+	w := New()
+	smoke := primitive.NewCube()
+	smoke.SetTransform(transform.Scaling(3, 3, 3))
+	smoke.SetMaterial(material.Default.
+		WithColor(floatcolor.Black).
+		WithVolumeDensity(0.5))
+	ball := primitive.NewSphere()
+	ball.SetMaterial(material.Default.WithAmbient(1).WithDiffuse(0).WithSpecular(0))
+	l := light.NewPointLight(tuple.NewPoint(0, 0, -10), floatcolor.White)
+	w.AddLights(&l)
+	w.AddPrimitives(&smoke, &ball)
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	c := w.ColorAt(r, 1)
+
+	// The ball's surface is 2 units inside the smoke
+	test.AssertAlmost(t, floatcolor.White.Mul(math.Exp(-1)), c)
+}
+
+func TestVolumesCastPartialShadows(t *testing.T) {
+	w := testWorld()
+	smoke := primitive.NewSphere()
+	smoke.SetTransform(transform.Translation(-5, 5, -5))
+	smoke.SetMaterial(material.Default.WithVolumeDensity(1))
+	w.AddPrimitives(&smoke)
+	p := tuple.NewPoint(-2, 2, -2)
+
+	// The light passes 2 units through the smoke.
+	test.AssertAlmost(t, math.Exp(-2), w.lightTransmittance(p, w.Lights()[0], 0))
+}
+
+func TestLightTransmittanceFromInsideVolume(t *testing.T) {
+	w := New()
+	l := light.NewPointLight(tuple.NewPoint(0, 10, 0), floatcolor.White)
+	w.AddLights(&l)
+	smoke := primitive.NewSphere()
+	smoke.SetTransform(transform.Scaling(2, 2, 2))
+	smoke.SetMaterial(material.Default.WithVolumeDensity(0.5))
+	w.AddPrimitives(&smoke)
+
+	test.AssertAlmost(t, math.Exp(-1), w.lightTransmittance(tuple.NewPoint(0, 0, 0), &l, 0))
+}
+
+func TestLightTransmittanceThroughPatchyVolume(t *testing.T) {
+	w := New()
+	l := light.NewPointLight(tuple.NewPoint(0, 10, 0), floatcolor.White)
+	w.AddLights(&l)
+	smoke := primitive.NewSphere()
+	smoke.SetTransform(transform.Scaling(2, 2, 2))
+	smoke.SetMaterial(material.Default.
+		WithVolumeDensity(0.5).
+		WithChannel(material.VolumeDensityChannel, material.SolidPattern(floatcolor.New(0.5, 0.5, 0.5))))
+	w.AddPrimitives(&smoke)
+
+	test.AssertAlmost(t, math.Exp(-0.5), w.lightTransmittance(tuple.NewPoint(0, 0, 0), &l, 0))
+}
+
+func TestLightTransmittanceThroughFog(t *testing.T) {
+	w := New()
+	l := light.NewPointLight(tuple.NewPoint(0, 10, 0), floatcolor.White)
+	sun := light.NewDirectionalLight(tuple.NewVector(0, -1, 0), floatcolor.White)
+	w.AddLights(&l, &sun)
+	w.SetFog(material.Default.WithVolumeDensity(0.1))
+	smoke := primitive.NewSphere()
+	smoke.SetTransform(transform.Translation(0, 5, 0))
+	smoke.SetMaterial(material.Default.WithVolumeDensity(0.5))
+	w.AddPrimitives(&smoke)
+	p := tuple.NewPoint(0, 0, 0)
+
+	// 8 units through the fog and 2 through the smoke.
+	test.AssertAlmost(t, math.Exp(-0.8-1), w.lightTransmittance(p, &l, 0))
+	// Sunlight comes from outside the fog.
+	test.AssertAlmost(t, math.Exp(-1), w.lightTransmittance(p, &sun, 0))
+}
+
+func TestSurfaceInsideVolumeIsDimlyLit(t *testing.T) {
+	w := New()
+	l := light.NewPointLight(tuple.NewPoint(0, 0, -10), floatcolor.White)
+	w.AddLights(&l)
+	water := primitive.NewCube()
+	water.SetTransform(transform.Scaling(3, 3, 3))
+	water.SetMaterial(material.Default.WithColor(floatcolor.Black).WithVolumeDensity(0.5))
+	ball := primitive.NewSphere()
+	ball.SetMaterial(material.Default.WithAmbient(0).WithDiffuse(1).WithSpecular(0))
+	w.AddPrimitives(&water, &ball)
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	c := w.ColorAt(r, 1)
+
+	// The light and the view each pass 2 units through the water to reach the ball.
+	test.AssertAlmost(t, floatcolor.White.Mul(math.Exp(-2)), c)
+}
@@ -5,9 +5,11 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/danieltmartin/ray-tracer/environment"
 	"github.com/danieltmartin/ray-tracer/float"
 	"github.com/danieltmartin/ray-tracer/floatcolor"
 	"github.com/danieltmartin/ray-tracer/light"
+	"github.com/danieltmartin/ray-tracer/material"
 	"github.com/danieltmartin/ray-tracer/primitive"
 	"github.com/danieltmartin/ray-tracer/ray"
 	"github.com/danieltmartin/ray-tracer/tuple"
@@ -30,6 +32,10 @@ type World struct {
 	primitives []primitive.Primitive
 	lights     []*light.PointLight
 	stats      *Stats
+	fog        material.Material
+
+	background         environment.Background
+	environmentSamples int
 }
 
 func New() *World {
@@ -73,19 +79,35 @@ func (w *World) intersect(r ray.Ray) primitive.Intersections {
 
 func (w *World) ColorAt(ray ray.Ray, remaining int) floatcolor.Float64Color {
 	w.stats.eyeRayCount.inc()
-	return w.castRay(ray, remaining)
+	return w.castRay(ray, remaining, false)
 }
 
-func (w *World) castRay(ray ray.Ray, remaining int) floatcolor.Float64Color {
-	xns := w.intersect(ray)
+// castRay returns the color seen along r. branched reports whether the path r belongs to has
+// already split into many samples at a rough surface.
+func (w *World) castRay(r ray.Ray, remaining int, branched bool) floatcolor.Float64Color {
+	xns := w.intersect(r)
 	hit := xns.Hit()
 	if hit == nil {
-		return floatcolor.Black
+		return w.throughMedium(r, math.Inf(1), nil, w.backgroundColor(r.Direction()))
 	}
-	hc := prepareHitComputations(*hit, ray, xns...)
+	hc := prepareHitComputations(*hit, r, xns...)
+	hc.branched = branched
 	reslice := xns[:0]
 	intersectionPool.Put(&reslice)
-	return w.shadeHit(hc, remaining)
+
+	var color floatcolor.Float64Color
+	if isVolume(hc.object) {
+		// The boundary of a volume isn't a visible surface so carry on through it.
+		direction := r.Direction()
+		origin := hc.hitPoint.Add(direction.Norm().Mul(float.Epsilon))
+		next := ray.New(origin, direction).WithTime(r.Time()).WithWavelength(r.Wavelength())
+		color = w.castRay(next, remaining, branched)
+	} else {
+		color = w.shadeHit(hc, remaining)
+	}
+
+	// Light leaving the hit is affected by whatever the ray travelled through to reach it.
+	return w.throughMedium(r, hc.distance, hc.medium, color)
 }
 
 func (w *World) shadeHit(hc hitComputations, remaining int) floatcolor.Float64Color {
@@ -94,67 +116,166 @@ func (w *World) shadeHit(hc hitComputations, remaining int) floatcolor.Float64Co
 		if light == nil {
 			continue
 		}
-		inShadow := w.isShadowed(hc.overPoint, light.Position())
-		hitColor := hc.object.Material().Lighting(hc.object, *light, hc.overPoint, hc.eyev, hc.normalv, inShadow)
+		transmittance := w.lightTransmittance(hc.overPoint, light, hc.time)
+		hitColor := hc.material.Lighting(hc.posed, *light, hc.overPoint, hc.eyev, hc.normalv, transmittance == 0)
+		if transmittance > 0 && transmittance < 1 {
+			// Only the light that reaches the surface is dimmed, not the ambient light.
+			unlit := hc.material.Lighting(hc.posed, *light, hc.overPoint, hc.eyev, hc.normalv, true)
+			hitColor = unlit.Add(hitColor.Sub(unlit).Mul(transmittance))
+		}
 		surfaceColor = surfaceColor.Add(hitColor)
 	}
+	if w.environmentSamples > 0 && w.background != nil {
+		m := hc.material
+		radiance := w.environmentRadiance(hc)
+		surfaceColor = surfaceColor.Add(m.EnvironmentLighting(hc.posed, hc.overPoint, radiance))
+	}
 	reflectColor := w.reflectedColor(hc, remaining)
 	refractColor := w.refractedColor(hc, remaining)
-	if hc.object.Material().Reflective() > 0 && hc.object.Material().Transparency() > 0 {
+	if hc.material.Reflective() > 0 && hc.material.Transparency() > 0 {
 		reflectance := schlick(hc)
 		return surfaceColor.Add(reflectColor.Mul(reflectance)).Add(refractColor.Mul(1 - reflectance))
 	}
 	return surfaceColor.Add(reflectColor).Add(refractColor)
 }
 
-func (w *World) isShadowed(p tuple.Tuple, lightPosition tuple.Tuple) bool {
-	w.stats.shadowRayCount.inc()
-	pointToLight := lightPosition.Sub(p)
-	lightDistance := pointToLight.Mag()
-	xns := w.intersect(ray.New(p, pointToLight.Norm()))
-	hit := xns.Hit()
+// blocked reports whether a surface lies along r closer than maxDistance.
+func (w *World) blocked(r ray.Ray, maxDistance float64) bool {
+	xns := w.intersect(r)
+	blocked := false
+	for _, x := range xns {
+		// Volumes scatter light rather than blocking it.
+		if x.Distance() >= 0 && x.Distance() < maxDistance && !isVolume(x.Object()) {
+			blocked = true
+			break
+		}
+	}
 	reslice := xns[:0]
 	intersectionPool.Put(&reslice)
-	return hit != nil && hit.Distance() < lightDistance
+	return blocked
 }
 
 func (w *World) reflectedColor(hc hitComputations, remaining int) floatcolor.Float64Color {
-	if remaining == 0 || hc.object.Material().Reflective() == 0 {
+	m := hc.material
+	if remaining == 0 || m.Reflective() == 0 {
 		return floatcolor.Black
 	}
-	w.stats.reflectionRayCount.inc()
-	reflectRay := ray.New(hc.overPoint, hc.reflectv)
-	return w.castRay(reflectRay, remaining-1).Mul(hc.object.Material().Reflective())
+
+	// Rough surfaces scatter the reflection across a lobe around the mirror direction.
+	samples := lobeSamples(m, hc.branched)
+	color := floatcolor.Black
+	for i := 0; i < samples; i++ {
+		w.stats.reflectionRayCount.inc()
+		direction := hc.reflectv
+		if m.Roughness() > 0 {
+			direction = glossyReflection(hc.eyev, hc.normalv, hc.reflectv, m.Roughness())
+		}
+		reflectRay := ray.New(hc.overPoint, direction).WithTime(hc.time).WithWavelength(hc.wavelength)
+		color = color.Add(w.castRay(reflectRay, remaining-1, hc.branched || m.Roughness() > 0))
+	}
+
+	return color.Mul(m.Reflective() / float64(samples))
 }
 
 func (w *World) refractedColor(hc hitComputations, remaining int) floatcolor.Float64Color {
+	m := hc.material
 	if remaining == 0 {
 		return floatcolor.Black
 	}
-	if hc.object.Material().Transparency() == 0 {
+	if m.Transparency() == 0 {
 		return floatcolor.Black
 	}
 
+	if hc.wavelength != 0 {
+		// The ray was split into colors at an earlier surface and bends as its own color does.
+		n1 := refractiveIndexAt(hc, hc.medium, hc.wavelength)
+		n2 := refractiveIndexAt(hc, hc.nextMedium, hc.wavelength)
+		return w.refractedColorWithIndices(hc, m, n1, n2, hc.wavelength, remaining).Mul(m.Transparency())
+	}
+	if !isDispersive(hc.medium) && !isDispersive(hc.nextMedium) {
+		return w.refractedColorWithIndices(hc, m, hc.n1, hc.n2, 0, remaining).Mul(m.Transparency())
+	}
+
+	// Each color channel bends by a different amount, so trace them separately. Each ray keeps
+	// its wavelength from here on, so later dispersive surfaces don't split it again.
+	color := floatcolor.Black
+	for _, s := range spectrum {
+		n1 := refractiveIndexAt(hc, hc.medium, s.wavelength)
+		n2 := refractiveIndexAt(hc, hc.nextMedium, s.wavelength)
+		channelColor := w.refractedColorWithIndices(hc, m, n1, n2, s.wavelength, remaining)
+		color = color.Add(channelColor.Hadamard(s.channel))
+	}
+	return color.Mul(m.Transparency())
+}
+
+// refractedColorWithIndices traces the refraction of light of the given wavelength, or of all
+// wavelengths if it is 0, between media with refractive indices n1 and n2.
+func (w *World) refractedColorWithIndices(hc hitComputations, m material.Material, n1, n2, wavelength float64, remaining int) floatcolor.Float64Color {
 	// Total internal reflection check
-	nRatio := hc.n1 / hc.n2
+	nRatio := n1 / n2
 	cosi := hc.eyev.Dot(hc.normalv)
 	sin2t := nRatio * nRatio * (1 - cosi*cosi)
 	if sin2t > 1 {
 		return floatcolor.Black
 	}
 
-	w.stats.refractionRayCount.inc()
-
 	cost := math.Sqrt(1.0 - sin2t)
 	direction := hc.normalv.Mul(nRatio*cosi - cost).Sub(hc.eyev.Mul(nRatio))
-	refractRay := ray.New(hc.underPoint, direction)
 
-	return w.castRay(refractRay, remaining-1).Mul(hc.object.Material().Transparency())
+	// Rough surfaces scatter the refraction across a lobe around the refracted direction,
+	// giving a frosted appearance.
+	samples := lobeSamples(m, hc.branched)
+	color := floatcolor.Black
+	for i := 0; i < samples; i++ {
+		w.stats.refractionRayCount.inc()
+		sampleDirection := direction
+		if m.Roughness() > 0 {
+			sampleDirection = frostedRefraction(hc.eyev, hc.normalv, direction, nRatio, m.Roughness())
+		}
+		refractRay := ray.New(hc.underPoint, sampleDirection).WithTime(hc.time).WithWavelength(wavelength)
+		color = color.Add(w.castRay(refractRay, remaining-1, hc.branched || m.Roughness() > 0))
+	}
+
+	return color.Mul(1 / float64(samples))
+}
+
+// spectrum lists the wavelength traced for each color channel when refracting through a
+// dispersive material.
+var spectrum = []struct {
+	wavelength float64
+	channel    floatcolor.Float64Color
+}{
+	{material.WavelengthC, floatcolor.Red},
+	{material.WavelengthD, floatcolor.Green},
+	{material.WavelengthF, floatcolor.Blue},
+}
+
+func isDispersive(medium primitive.Primitive) bool {
+	return medium != nil && medium.Material().Dispersive()
+}
+
+// refractiveIndexAt returns the refractive index of medium for wavelength where the ray of hc
+// crosses its surface.
+func refractiveIndexAt(hc hitComputations, medium primitive.Primitive, wavelength float64) float64 {
+	if medium == nil {
+		return 1.0
+	}
+	return materialAt(medium, hc.time, hc.hitPoint).RefractiveIndexAt(wavelength)
+}
+
+// materialAt returns the material of p with its texture channels evaluated at worldPoint at
+// time.
+func materialAt(p primitive.Primitive, time float64, worldPoint tuple.Tuple) material.Material {
+	return p.Material().At(primitive.AtTime(p, time), worldPoint)
 }
 
 type hitComputations struct {
 	distance   float64
+	time       float64 // Time of the ray, which secondary rays share
+	wavelength float64 // Wavelength of the ray if it has been split into colors, which secondary rays share
 	object     primitive.Primitive
+	posed      material.Object   // The object as it is at time, for evaluating its patterns
+	material   material.Material // Material of the object with its texture channels evaluated at the hit
 	hitPoint   tuple.Tuple
 	overPoint  tuple.Tuple // Adjusted in normalv direction slightly for floating point precision sensitive calculations
 	underPoint tuple.Tuple
@@ -164,6 +285,9 @@ type hitComputations struct {
 	inside     bool
 	n1         float64
 	n2         float64
+	medium     primitive.Primitive // Object the ray travelled through to reach the hit, or nil for empty space
+	nextMedium primitive.Primitive // Object the ray continues into after the hit, or nil for empty space
+	branched   bool                // Whether the path has already split into many samples at a rough surface
 }
 
 func prepareHitComputations(
@@ -173,8 +297,12 @@ func prepareHitComputations(
 	var hc hitComputations
 
 	hc.distance = hit.Distance()
+	hc.time = ray.Time()
+	hc.wavelength = ray.Wavelength()
 	hc.object = hit.Object()
+	hc.posed = primitive.AtTime(hc.object, hc.time)
 	hc.hitPoint = ray.Position(hit.Distance())
+	hc.material = hc.object.Material().At(hc.posed, hc.hitPoint)
 	hc.eyev = ray.Direction().Neg()
 	hc.normalv = hc.object.NormalAt(hc.hitPoint, hit)
 	hc.reflectv = ray.Direction().Reflect(hc.normalv)
@@ -187,13 +315,25 @@ func prepareHitComputations(
 	hc.overPoint = hc.hitPoint.Add(hc.normalv.Mul(float.Epsilon))
 	hc.underPoint = hc.hitPoint.Sub(hc.normalv.Mul(float.Epsilon))
 
+	// Bump and normal maps only change the normal used for shading; the over and under points
+	// must still be offset from the true surface.
+	if m := hc.material; m.Perturbation() != nil {
+		shadingNormal := m.PerturbNormal(hc.posed, hc.hitPoint, hc.normalv)
+		// Tilting the normal away from the eye would shade the surface as if seen from behind.
+		if shadingNormal.Dot(hc.eyev) > 0 {
+			hc.normalv = shadingNormal
+			hc.reflectv = ray.Direction().Reflect(hc.normalv)
+		}
+	}
+
 	var containers []primitive.Primitive
 	for _, x := range allIntersections {
 		if x == hit {
 			if len(containers) == 0 {
 				hc.n1 = 1.0
 			} else {
-				hc.n1 = containers[len(containers)-1].Material().RefractiveIndex()
+				hc.medium = containers[len(containers)-1]
+				hc.n1 = materialAt(hc.medium, hc.time, hc.hitPoint).RefractiveIndex()
 			}
 		}
 
@@ -208,7 +348,8 @@ func prepareHitComputations(
 			if len(containers) == 0 {
 				hc.n2 = 1.0
 			} else {
-				hc.n2 = containers[len(containers)-1].Material().RefractiveIndex()
+				hc.nextMedium = containers[len(containers)-1]
+				hc.n2 = materialAt(hc.nextMedium, hc.time, hc.hitPoint).RefractiveIndex()
 			}
 			break
 		}
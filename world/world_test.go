@@ -51,6 +51,39 @@ func TestPrecomputingIntersectionState(t *testing.T) {
 	assert.Equal(t, tuple.NewVector(0, 0, -1), hc.normalv)
 }
 
+func rampBumpMap(scale float64) material.BumpMap {
+	ramp := material.NewFuncPattern(func(p material.PatternPoint) floatcolor.Float64Color {
+		return floatcolor.New(p.Local.X, p.Local.X, p.Local.X)
+	})
+	return material.NewBumpMap(ramp, scale)
+}
+
+func TestPrecomputingPerturbedNormal(t *testing.T) {
+	s := primitive.NewPlane()
+	s.SetMaterial(material.Default.WithPerturbation(rampBumpMap(1)))
+	r := ray.New(tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0))
+	i := primitive.NewIntersection(1, &s)
+
+	hc := prepareHitComputations(i, r)
+
+	test.AssertAlmost(t, tuple.NewVector(-1, 1, 0).Norm(), hc.normalv)
+	test.AssertAlmost(t, tuple.NewVector(0, 1, 0).Reflect(hc.normalv).Neg(), hc.reflectv)
+	// The over and under points are still offset along the true normal.
+	assert.Equal(t, tuple.NewPoint(0, float.Epsilon, 0), hc.overPoint)
+	assert.Equal(t, tuple.NewPoint(0, -float.Epsilon, 0), hc.underPoint)
+}
+
+func TestPerturbedNormalFacingAwayFromEyeIsIgnored(t *testing.T) {
+	s := primitive.NewPlane()
+	s.SetMaterial(material.Default.WithPerturbation(rampBumpMap(10)))
+	r := ray.New(tuple.NewPoint(1, 0.1, 0), tuple.NewVector(-10, -1, 0).Norm())
+	i := primitive.NewIntersection(math.Sqrt(101)/10, &s)
+
+	hc := prepareHitComputations(i, r)
+
+	assert.Equal(t, tuple.NewVector(0, 1, 0), hc.normalv)
+}
+
 func TestPrecomputingReflectionVector(t *testing.T) {
 	s := primitive.NewPlane()
 	r := ray.New(tuple.NewPoint(0, 1, -1), tuple.NewVector(0, -math.Sqrt2/2, math.Sqrt2/2))
@@ -126,6 +159,41 @@ func TestPrecomputingEnterAndExitPoint(t *testing.T) {
 	}
 }
 
+func TestRefractiveIndexChannelIsEvaluatedAtHit(t *testing.T) {
+	s := glassSphere()
+	s.SetMaterial(s.Material().WithChannel(material.RefractiveIndexChannel, material.SolidPattern(floatcolor.New(0.5, 0.5, 0.5))))
+	r := ray.New(tuple.NewPoint(0, 0, -4), tuple.NewVector(0, 0, 1))
+	xs := primitive.NewIntersections(primitive.NewIntersection(3, s), primitive.NewIntersection(5, s))
+
+	entering := prepareHitComputations(xs[0], r, xs...)
+	leaving := prepareHitComputations(xs[1], r, xs...)
+
+	assert.Equal(t, 1.0, entering.n1)
+	assert.Equal(t, 1.25, entering.n2)
+	assert.Equal(t, 1.25, leaving.n1)
+	assert.Equal(t, 1.0, leaving.n2)
+}
+
+func TestPrecomputingMedium(t *testing.T) {
+	a := glassSphere()
+	a.SetTransform(transform.Scaling(2, 2, 2))
+	b := glassSphere()
+	r := ray.New(tuple.NewPoint(0, 0, -4), tuple.NewVector(0, 0, 1))
+	xs := primitive.NewIntersections(
+		primitive.NewIntersection(2, a),
+		primitive.NewIntersection(3, b),
+		primitive.NewIntersection(5, b),
+		primitive.NewIntersection(6, a),
+	)
+
+	expected := []primitive.Primitive{nil, a, b, a}
+
+	for i, x := range xs {
+		hc := prepareHitComputations(x, r, xs...)
+		assert.Equal(t, expected[i], hc.medium)
+	}
+}
+
 func TestHitOutside(t *testing.T) {
 	w := testWorld()
 	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
@@ -289,28 +357,53 @@ func TestNoShadowWhenNothingBetweenIntersectionAndLight(t *testing.T) {
 	w := testWorld()
 	p := tuple.NewPoint(0, 10, 0)
 
-	assert.False(t, w.isShadowed(p, w.Lights()[0].Position()))
+	assert.Equal(t, 1.0, w.lightTransmittance(p, w.Lights()[0], 0))
 }
 
 func TestShadowWhenObjectIsBetweenIntersectionAndLight(t *testing.T) {
 	w := testWorld()
 	p := tuple.NewPoint(10, -10, 10)
 
-	assert.True(t, w.isShadowed(p, w.Lights()[0].Position()))
+	assert.Equal(t, 0.0, w.lightTransmittance(p, w.Lights()[0], 0))
 }
 
 func TestNoShadowWhenObjectIsBehindLight(t *testing.T) {
 	w := testWorld()
 	p := tuple.NewPoint(-20, 20, -20)
 
-	assert.False(t, w.isShadowed(p, w.Lights()[0].Position()))
+	assert.Equal(t, 1.0, w.lightTransmittance(p, w.Lights()[0], 0))
 }
 
 func TestNoShadowWhenObjectIsBehindPoint(t *testing.T) {
 	w := testWorld()
 	p := tuple.NewPoint(-2, 2, -2)
 
-	assert.False(t, w.isShadowed(p, w.Lights()[0].Position()))
+	assert.Equal(t, 1.0, w.lightTransmittance(p, w.Lights()[0], 0))
+}
+
+func TestDirectionalLightIsBlockedAtAnyDistance(t *testing.T) {
+	w := testWorld()
+	sun := light.NewDirectionalLight(tuple.NewVector(0, -1, 0), floatcolor.White)
+
+	assert.Equal(t, 0.0, w.lightTransmittance(tuple.NewPoint(0, -100, 0), &sun, 0))
+	assert.Equal(t, 1.0, w.lightTransmittance(tuple.NewPoint(5, -100, 0), &sun, 0))
+}
+
+func TestShadingWithDirectionalLight(t *testing.T) {
+	w := New()
+	floor := primitive.NewPlane()
+	floor.SetMaterial(material.Default.WithAmbient(0).WithSpecular(0))
+	blocker := primitive.NewSphere()
+	blocker.SetTransform(transform.Translation(0, 50, 0))
+	w.AddPrimitives(&floor, &blocker)
+	sun := light.NewDirectionalLight(tuple.NewVector(0, -1, 0), floatcolor.White)
+	w.AddLights(&sun)
+
+	lit := w.ColorAt(ray.New(tuple.NewPoint(5, 1, 0), tuple.NewVector(0, -1, 0)), 1)
+	shadowed := w.ColorAt(ray.New(tuple.NewPoint(0, 1, 0), tuple.NewVector(0, -1, 0)), 1)
+
+	test.AssertAlmost(t, floatcolor.New(0.9, 0.9, 0.9), lit)
+	assert.Equal(t, floatcolor.Black, shadowed)
 }
 
 func TestReflectedColorForNonReflectiveMaterial(t *testing.T) {
@@ -341,6 +434,31 @@ func TestReflectedColorForReflectiveMaterial(t *testing.T) {
 	assert.EqualValues(t, 1, w.stats.ReflectionRayCount())
 }
 
+func TestReflectedColorUsesReflectivityMask(t *testing.T) {
+	w := testWorld()
+	r := ray.New(tuple.NewPoint(0, 0, -3), tuple.NewVector(0, -math.Sqrt2/2, math.Sqrt2/2))
+	shape := primitive.NewPlane()
+	shape.SetTransform(transform.Translation(0, -1, 0))
+	w.AddPrimitives(&shape)
+	i := primitive.NewIntersection(math.Sqrt2, &shape)
+	// The ray hits the plane at x = 0, in the first stripe.
+	shape.SetMaterial(material.Default.
+		WithReflective(1).
+		WithChannel(material.ReflectiveChannel, material.NewStripePattern(floatcolor.New(0.5, 0.5, 0.5), floatcolor.Black)))
+
+	hc := prepareHitComputations(i, r)
+
+	assert.Equal(t, 0.5, hc.material.Reflective())
+	test.AssertAlmost(t, floatcolor.New(0.19032, 0.2379, 0.14274), w.reflectedColor(hc, 1))
+
+	shape.SetMaterial(shape.Material().
+		WithChannel(material.ReflectiveChannel, material.NewStripePattern(floatcolor.Black, floatcolor.White)))
+
+	hc = prepareHitComputations(i, r)
+
+	assert.Equal(t, floatcolor.Black, w.reflectedColor(hc, 1))
+}
+
 func TestShadeHitForReflectiveMaterial(t *testing.T) {
 	w := testWorld()
 	r := ray.New(tuple.NewPoint(0, 0, -3), tuple.NewVector(0, -math.Sqrt2/2, math.Sqrt2/2))
@@ -393,6 +511,47 @@ func TestColorAtWithMutuallyReflectiveSurfaces(t *testing.T) {
 	// Should not overflow stack from infinite recursion
 }
 
+func TestReflectedColorForRoughMaterialTracesMultipleSamples(t *testing.T) {
+	w := testWorld()
+	r := ray.New(tuple.NewPoint(0, 0, -3), tuple.NewVector(0, -math.Sqrt2/2, math.Sqrt2/2))
+	shape := primitive.NewPlane()
+	shape.SetMaterial(material.Default.
+		WithReflective(0.5).
+		WithRoughness(0.3).
+		WithRoughnessSamples(6))
+	shape.SetTransform(transform.Translation(0, -1, 0))
+	w.AddPrimitives(&shape)
+	i := primitive.NewIntersection(math.Sqrt2, &shape)
+
+	hc := prepareHitComputations(i, r)
+	c := w.reflectedColor(hc, 1)
+
+	assert.EqualValues(t, 6, w.stats.ReflectionRayCount())
+	r0, g0, b0 := c.RGB()
+	assert.True(t, r0 >= 0 && g0 >= 0 && b0 >= 0)
+	assert.True(t, r0 <= 0.5 && g0 <= 0.5 && b0 <= 0.5)
+}
+
+func TestRoughReflectionsBranchOnlyAtFirstHit(t *testing.T) {
+	w := New()
+	l := light.NewPointLight(tuple.NewPoint(0, 0, 0), floatcolor.White)
+	w.AddLights(&l)
+	rough := material.Default.WithReflective(1).WithRoughness(0.2).WithRoughnessSamples(4)
+	lower := primitive.NewPlane()
+	lower.SetMaterial(rough)
+	lower.SetTransform(transform.Translation(0, -1, 0))
+	upper := primitive.NewPlane()
+	upper.SetMaterial(rough)
+	upper.SetTransform(transform.Translation(0, 1, 0))
+	w.AddPrimitives(&lower, &upper)
+
+	ray := ray.New(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 1, 0))
+	w.ColorAt(ray, 3)
+
+	// 4 samples at the first hit, then one ray for each of them at the next two.
+	assert.EqualValues(t, 12, w.stats.ReflectionRayCount())
+}
+
 func TestRefractedColorWithOpaqueSurface(t *testing.T) {
 	w := testWorld()
 	shape := w.primitives[0]
@@ -476,6 +635,119 @@ func TestRefractedColorWithRefractedRay(t *testing.T) {
 	assert.EqualValues(t, 1, w.stats.RefractionRayCount())
 }
 
+func TestRefractedColorForFrostedMaterialTracesMultipleSamples(t *testing.T) {
+	w := testWorld()
+	a := w.primitives[0]
+	a.SetMaterial(a.Material().
+		WithAmbient(1.0).
+		WithPattern(material.TestPattern{}),
+	)
+	b := w.primitives[1]
+	b.SetMaterial(b.Material().
+		WithTransparency(1.0).
+		WithRefractiveIndex(1.5).
+		WithRoughness(0.2).
+		WithRoughnessSamples(4),
+	)
+	r := ray.New(tuple.NewPoint(0, 0, 0.1), tuple.NewVector(0, 1, 0))
+	xs := primitive.NewIntersections(
+		primitive.NewIntersection(-0.9899, a),
+		primitive.NewIntersection(-0.4899, b),
+		primitive.NewIntersection(0.4899, b),
+		primitive.NewIntersection(0.9899, a),
+	)
+
+	hc := prepareHitComputations(xs[2], r, xs...)
+	w.refractedColor(hc, 5)
+
+	assert.EqualValues(t, 4, w.stats.RefractionRayCount())
+}
+
+func TestColorThroughAbsorbingMaterial(t *testing.T) {
+	w := New()
+	l := light.NewPointLight(tuple.NewPoint(0, 0, -5), floatcolor.White)
+	w.AddLights(&l)
+	room := primitive.NewSphere()
+	room.SetTransform(transform.Scaling(10, 10, 10))
+	room.SetMaterial(material.Default.WithAmbient(1).WithDiffuse(0).WithSpecular(0))
+	liquid := primitive.NewSphere()
+	liquid.SetMaterial(material.Default.
+		WithAmbient(0).
+		WithDiffuse(0).
+		WithSpecular(0).
+		WithTransparency(1).
+		WithAbsorption(floatcolor.Red, 1))
+	w.AddPrimitives(&room, &liquid)
+	r := ray.New(tuple.NewPoint(0, 0, 0), tuple.NewVector(0, 0, 1))
+
+	c := w.ColorAt(r, 5)
+
+	test.AssertAlmost(t, floatcolor.New(1, math.Exp(-1), math.Exp(-1)), c)
+}
+
+func TestRefractedColorForDispersiveMaterialTracesEachChannel(t *testing.T) {
+	w := testWorld()
+	a := w.primitives[0]
+	a.SetMaterial(a.Material().
+		WithAmbient(1.0).
+		WithPattern(material.TestPattern{}),
+	)
+	b := w.primitives[1]
+	b.SetMaterial(b.Material().
+		WithTransparency(1.0).
+		WithRefractiveIndex(1.5).
+		WithAbbeNumber(10),
+	)
+	r := ray.New(tuple.NewPoint(0, 0, 0.1), tuple.NewVector(0, 1, 0))
+	xs := primitive.NewIntersections(
+		primitive.NewIntersection(-0.9899, a),
+		primitive.NewIntersection(-0.4899, b),
+		primitive.NewIntersection(0.4899, b),
+		primitive.NewIntersection(0.9899, a),
+	)
+
+	hc := prepareHitComputations(xs[2], r, xs...)
+	c := w.refractedColor(hc, 5)
+
+	assert.EqualValues(t, 3, w.stats.RefractionRayCount())
+	// The test pattern encodes the point hit. Green is traced at the wavelength where the index
+	// is 1.5 so it matches the non-dispersive case, while blue bends further.
+	_, green, blue := c.RGB()
+	test.AssertAlmost(t, 0.99888, green)
+	assert.Greater(t, math.Abs(blue-0.04725), 0.001)
+}
+
+func TestDispersedRaysAreNotSplitAgain(t *testing.T) {
+	w := New()
+	prism := primitive.NewSphere()
+	prism.SetMaterial(material.Default.
+		WithAmbient(0).
+		WithTransparency(1).
+		WithRefractiveIndex(1.5).
+		WithAbbeNumber(10))
+	w.AddPrimitives(&prism)
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1))
+
+	w.ColorAt(r, 5)
+
+	// Split into three colors on the way in, and each leaves as a single ray.
+	assert.EqualValues(t, 6, w.stats.RefractionRayCount())
+}
+
+func TestRefractedColorOfRayWithWavelength(t *testing.T) {
+	s := glassSphere()
+	s.SetMaterial(s.Material().WithAbbeNumber(10))
+	r := ray.New(tuple.NewPoint(0, 0, -5), tuple.NewVector(0, 0, 1)).WithWavelength(material.WavelengthF)
+	xs := primitive.NewIntersections(primitive.NewIntersection(4, s), primitive.NewIntersection(6, s))
+	w := New()
+
+	hc := prepareHitComputations(xs[0], r, xs...)
+	w.refractedColor(hc, 5)
+
+	assert.Equal(t, material.WavelengthF, hc.wavelength)
+	assert.EqualValues(t, 1, w.stats.RefractionRayCount())
+}
+
 func TestSchlickApproximationUnderTotalInternalReflection(t *testing.T) {
 	s := glassSphere()
 	r := ray.New(tuple.NewPoint(0, 0, math.Sqrt2/2), tuple.NewVector(0, 1, 0))
@@ -565,3 +837,72 @@ func glassSphere() primitive.Primitive {
 	)
 	return &s
 }
+
+func TestFuncPatternReceivesUVOfHitPrimitive(t *testing.T) {
+	w := New()
+	s := primitive.NewSphere()
+	s.SetMaterial(material.Default.
+		WithPattern(material.NewFuncPattern(func(p material.PatternPoint) floatcolor.Float64Color {
+			return floatcolor.New(p.U, p.V, 1)
+		})).
+		WithAmbient(1).
+		WithDiffuse(0).
+		WithSpecular(0))
+	w.AddPrimitives(&s)
+	l := light.NewPointLight(tuple.NewPoint(10, 0, 0), floatcolor.White)
+	w.AddLights(&l)
+	r := ray.New(tuple.NewPoint(5, 0, 0), tuple.NewVector(-1, 0, 0))
+
+	c := w.ColorAt(r, 1)
+
+	test.AssertAlmost(t, floatcolor.New(0.25, 0.5, 1), c)
+}
+
+func TestInstancedGlassSphereLooksLikeSphere(t *testing.T) {
+	shade := func(p primitive.Primitive) floatcolor.Float64Color {
+		w := New()
+		floor := primitive.NewPlane()
+		floor.SetTransform(transform.Translation(0, -1, 0))
+		floor.SetMaterial(material.Default.WithPattern(material.TestPattern{}))
+		light := light.NewPointLight(tuple.NewPoint(-10, 10, -10), floatcolor.White)
+		w.AddLights(&light)
+		w.AddPrimitives(&floor, p)
+		return w.ColorAt(ray.New(tuple.NewPoint(0.2, 0.3, -5), tuple.NewVector(0, -0.1, 1).Norm()), 5)
+	}
+	translation := transform.Translation(1, 0, 0)
+	plain := glassSphere()
+	plain.SetTransform(translation)
+	instance := primitive.NewInstance(glassSphere())
+	instance.SetTransform(translation)
+
+	test.AssertAlmost(t, shade(plain), shade(instance))
+}
+
+func TestShadowOfMovingObjectDependsOnTime(t *testing.T) {
+	w := New()
+	l := light.NewPointLight(tuple.NewPoint(0, 10, 0), floatcolor.White)
+	w.AddLights(&l)
+	s := primitive.NewSphere()
+	s.SetMotion(transform.Between(transform.Translation(-5, 5, 0), transform.Translation(5, 5, 0)))
+	w.AddPrimitives(&s)
+	p := tuple.NewPoint(0, 0, 0)
+
+	assert.Equal(t, 1.0, w.lightTransmittance(p, &l, 0))
+	assert.Equal(t, 0.0, w.lightTransmittance(p, &l, 0.5))
+}
+
+func TestSecondaryRaysShareTime(t *testing.T) {
+	w := New()
+	mirror := primitive.NewPlane()
+	mirror.SetMaterial(material.Default.WithReflective(1).WithAmbient(0).WithDiffuse(0).WithSpecular(0))
+	s := primitive.NewSphere()
+	s.SetMaterial(material.Default.WithAmbient(1))
+	s.SetMotion(transform.Between(transform.Translation(-5, 3, 3), transform.Translation(5, 3, 3)))
+	l := light.NewPointLight(tuple.NewPoint(0, 10, -10), floatcolor.White)
+	w.AddLights(&l)
+	w.AddPrimitives(&mirror, &s)
+	r := ray.New(tuple.NewPoint(0, 1, -1), tuple.NewVector(0, -1, 1).Norm())
+
+	assert.Equal(t, floatcolor.Black, w.ColorAt(r, 5))
+	assert.NotEqual(t, floatcolor.Black, w.ColorAt(r.WithTime(0.5), 5))
+}